@@ -12,7 +12,7 @@ go mod vendor
 go build -o fileManager
 export pw=$PWD
 
-zip -r fileManager.zip ./fileManager ./web
+zip -r fileManager.zip ./fileManager ./web ./nas.json
 #scp fileManager.zip $1:/root/fileManager.zip
 rm fileManager
 
@@ -16,27 +16,37 @@
 package actions
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/saichler/l8nasfile/go/nas/archives"
+	files2 "github.com/saichler/l8nasfile/go/nas/files"
+	"github.com/saichler/l8nasfile/go/nas/jobs"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
 	"github.com/saichler/l8nasfile/go/types/files"
 	"github.com/saichler/l8srlz/go/serialize/object"
 	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8types/go/types/l8web"
 	"github.com/saichler/l8utils/go/utils/web"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 const (
 	ServiceName = "Actions"
 	ServiceType = "ActionService"
 	ServiceArea = byte(0)
+	// MaxActionSize caps the body of an action posted to the ActionHandler
+	MaxActionSize = 1 << 20
 )
 
 type ActionService struct {
@@ -65,157 +75,450 @@ func (this *ActionService) DeActivate() error {
 	return nil
 }
 
-func responde(msg string, isError bool) ifs.IElements {
-	fmt.Println(msg)
-	return object.New(nil, &files.ActionResponse{Msg: msg, IsError: isError})
+func responde(resp *files.ActionResponse) ifs.IElements {
+	fmt.Println(resp.Msg)
+	return object.New(nil, resp)
+}
+
+func failure(msg string) *files.ActionResponse {
+	return &files.ActionResponse{Msg: msg, IsError: true}
+}
+
+// runJob runs the action as a job of the user, so it can be followed and
+// cancelled. An async action returns the job id right away, otherwise the
+// job is waited on.
+func runJob(ac *files.Action, user string, do func(*files.Action, *report) *files.ActionResponse) *files.ActionResponse {
+	job := jobs.Submit(ac.Action, describe(ac), user, func(job *jobs.Job) *files.ActionResponse {
+		return do(ac, &report{job: job, policy: ac.ConflictPolicy, user: user})
+	})
+	if ac.Async {
+		return &files.ActionResponse{JobId: job.Id(), Msg: "Started job " + job.Id()}
+	}
+	job.Wait()
+	resp := job.Snapshot().Response
+	resp.JobId = job.Id()
+	return resp
+}
+
+func describe(ac *files.Action) string {
+	description := ac.Action.String()
+	if ac.Source != nil {
+		description += " " + shares.VirtualPath(ac.Source)
+	}
+	if len(ac.Sources) > 0 {
+		description += " " + strconv.Itoa(len(ac.Sources)) + " items"
+	}
+	if ac.Target != nil {
+		description += " to " + shares.VirtualPath(ac.Target)
+	}
+	return description
+}
+
+// destination returns where a copied or moved source ends up. An existing
+// target directory receives the source under its own name, any other target
+// is the exact destination path. Anything already existing at the
+// destination is a conflict.
+func destination(sourcePath, targetPath string) string {
+	info, err := os.Stat(targetPath)
+	if err == nil && info.IsDir() && targetPath != sourcePath {
+		return filepath.Join(targetPath, filepath.Base(sourcePath))
+	}
+	return targetPath
 }
 
 func isDirectory(source, target *files.File) (string, string, error) {
 	if source == nil || target == nil {
 		return "", "", errors.New("source or target are nil")
 	}
-	sourcePath := source.Path + "/" + source.Name
-	if strings.HasSuffix(sourcePath, "//") {
-		sourcePath = sourcePath[1:]
+	sourcePath, err := shares.ResolveFile(source)
+	if err != nil {
+		return "", "", err
 	}
-	_, err := os.ReadDir(sourcePath)
-	if err == nil {
-		source.IsDirectory = true
-	} else {
-		source.IsDirectory = false
-		_, err = os.Stat(sourcePath)
-		if err != nil {
-			return "", "", errors.New("Source '" + sourcePath + "' does not exist")
-		}
+	sourceInfo, err := os.Stat(sourcePath)
+	if err != nil {
+		return "", "", errors.New("Source '" + shares.VirtualPath(source) + "' does not exist")
 	}
+	source.IsDirectory = sourceInfo.IsDir()
 
-	targetPath := target.Path + "/" + target.Name
-	if strings.HasSuffix(targetPath, "//") {
-		targetPath = targetPath[1:]
+	targetPath, err := shares.ResolveFile(target)
+	if err != nil {
+		return "", "", err
 	}
-	_, err = os.ReadDir(targetPath)
+	targetInfo, err := os.Stat(targetPath)
 	if err == nil {
-		target.IsDirectory = true
+		target.IsDirectory = targetInfo.IsDir()
 	} else {
-		_, err = os.Stat(targetPath)
-		if err == nil && source.IsDirectory {
-			return "", "", errors.New("Target '" + targetPath + "' is a file")
-		} else {
-			target.IsDirectory = source.IsDirectory
-		}
+		target.IsDirectory = source.IsDirectory
 	}
 
-	return "\"" + sourcePath + "\"", "\"" + targetPath + "\"", nil
+	return sourcePath, targetPath, nil
 }
 
+// Post runs the action of the service callers, which are not identified, so
+// they run actions as an anonymous user.
 func (this *ActionService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
 	ac, ok := pb.Element().(*files.Action)
-	if ok {
-		fmt.Println("Doing: ", ac.Action.String())
-		switch ac.Action {
-		case files.ActionType_copy:
-			return doCopy(ac)
-		case files.ActionType_cut:
-			return doCut(ac)
-		case files.ActionType_delete:
-			return doDelete(ac)
-		case files.ActionType_rename:
-			return doRename(ac)
-		case files.ActionType_newFolder:
-			return doNewFolder(ac)
-		}
+	if !ok {
+		return object.New(nil, &l8web.L8Empty{})
 	}
-	return object.New(nil, &l8web.L8Empty{})
+	return responde(Run(ac, ""))
 }
 
-func doCopy(ac *files.Action) ifs.IElements {
-	source, target, err := isDirectory(ac.Source, ac.Target)
+// Run runs the action as the user.
+func Run(ac *files.Action, user string) *files.ActionResponse {
+	fmt.Println("Doing: ", ac.Action.String())
+	if err := authorized(ac, user); err != nil {
+		return failure(err.Error())
+	}
+	// A compressed archive packs all the sources together
+	if len(ac.Sources) > 0 && ac.Action != files.ActionType_compress {
+		return doBatch(ac, user)
+	}
+	switch ac.Action {
+	case files.ActionType_copy:
+		return runJob(ac, user, doCopy)
+	case files.ActionType_cut:
+		return runJob(ac, user, doCut)
+	case files.ActionType_delete:
+		return runJob(ac, user, doDelete)
+	case files.ActionType_rename:
+		return doRename(ac, &report{policy: ac.ConflictPolicy, user: user})
+	case files.ActionType_newFolder:
+		return doNewFolder(ac, &report{user: user})
+	case files.ActionType_listTrash:
+		return doListTrash(ac, user)
+	case files.ActionType_restore:
+		return runJob(ac, user, doRestore)
+	case files.ActionType_purge:
+		return runJob(ac, user, doPurge)
+	case files.ActionType_chmod:
+		return runJob(ac, user, doChmod)
+	case files.ActionType_chown:
+		return runJob(ac, user, doChown)
+	case files.ActionType_symlink:
+		return doSymlink(ac, &report{policy: ac.ConflictPolicy, user: user})
+	case files.ActionType_hardlink:
+		return doHardlink(ac, &report{policy: ac.ConflictPolicy, user: user})
+	case files.ActionType_compress:
+		return runJob(ac, user, doCompress)
+	case files.ActionType_extract:
+		return runJob(ac, user, doExtract)
+	}
+	return failure("Unknown action '" + ac.Action.String() + "'")
+}
+
+// ActionHandler runs the action posted by the authenticated user.
+func ActionHandler(w http.ResponseWriter, r *http.Request, user string, resources ifs.IResources) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxActionSize))
+	if err != nil {
+		http.Error(w, "Action is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	ac := &files.Action{}
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, ac)
+	if err != nil {
+		http.Error(w, "Invalid action", http.StatusBadRequest)
+		return
+	}
+	err = writeJson(w, http.StatusOK, Run(ac, user))
 	if err != nil {
-		return responde(err.Error(), true)
+		resources.Logger().Error("Error writing action response: ", err)
 	}
+}
+
+// doBatch runs the action for each of the sources as a single job. The target,
+// if the action has one, is the directory receiving all the sources.
+func doBatch(ac *files.Action, user string) *files.ActionResponse {
+	var do func(*files.Action, *report) *files.ActionResponse
+	switch ac.Action {
+	case files.ActionType_copy:
+		do = doCopy
+	case files.ActionType_cut:
+		do = doCut
+	case files.ActionType_delete:
+		do = doDelete
+	case files.ActionType_newFolder:
+		do = doNewFolder
+	case files.ActionType_chmod:
+		do = doChmod
+	case files.ActionType_chown:
+		do = doChown
+	default:
+		return failure("Action '" + ac.Action.String() + "' does not support multiple sources")
+	}
+	return runJob(ac, user, func(ac *files.Action, rep *report) *files.ActionResponse {
+		return batch(ac, rep, do)
+	})
+}
 
-	script := ""
+// batch runs do on every source of the action. The outcome of every source is
+// reported in the response items, the entries that failed inside the sources
+// in the response results.
+func batch(ac *files.Action, rep *report, do func(*files.Action, *report) *files.ActionResponse) *files.ActionResponse {
+	if ac.Target != nil {
+		targetPath, err := shares.ResolveFile(ac.Target)
+		if err != nil {
+			return failure(err.Error())
+		}
+		if info, err := os.Stat(targetPath); err != nil || !info.IsDir() {
+			return failure("Target '" + shares.VirtualPath(ac.Target) + "' is not a directory")
+		}
+	}
+	for _, source := range ac.Sources {
+		if source == nil {
+			continue
+		}
+		sourcePath, err := shares.ResolveFile(source)
+		if err == nil {
+			rep.measure(sourcePath)
+		}
+	}
 
-	if ac.Source.IsDirectory && ac.Target.IsDirectory {
-		script = "bash -c 'cp -r " + source + " " + target + "'"
-	} else if !ac.Source.IsDirectory && !ac.Target.IsDirectory {
-		script = "bash -c 'cp " + source + " " + target + "'"
+	items := make([]*files.ActionResult, 0, len(ac.Sources))
+	failed := 0
+	for _, source := range ac.Sources {
+		if rep.stopped() {
+			break
+		}
+		item := &files.Action{Action: ac.Action, Source: source, Permanent: ac.Permanent,
+			Mode: ac.Mode, Owner: ac.Owner, Recursive: ac.Recursive}
+		if ac.Target != nil {
+			item.Target = &files.File{Path: ac.Target.Path, Name: ac.Target.Name}
+		}
+		itemRep := &report{job: rep.job, measured: true, policy: rep.policy, user: rep.user}
+		resp := failure("source is nil")
+		if source != nil {
+			resp = do(item, itemRep)
+		}
+		rep.merge(itemRep)
+		if source != nil {
+			items = append(items, &files.ActionResult{Path: shares.VirtualPath(source), IsError: resp.IsError, Msg: resp.Msg})
+		}
+		if resp.IsError {
+			failed++
+			if ac.StopOnError {
+				break
+			}
+		}
 	}
 
-	os.WriteFile("script.sh", []byte(script), 0777)
-	cmd := exec.Command("bash", "-c", "./script.sh")
-	out, err := cmd.Output()
-	if err != nil {
-		return responde(err.Error(), true)
+	resp := rep.response(ac.Action.String() + " of " + strconv.Itoa(len(items)) + " items completed")
+	resp.Items = items
+	if failed > 0 && !rep.cancelled {
+		resp.IsError = true
+		resp.Msg = strconv.Itoa(failed) + " of " + strconv.Itoa(len(ac.Sources)) + " items failed"
 	}
-	return responde(string(out), false)
+	return resp
 }
 
-func doCut(ac *files.Action) ifs.IElements {
+func doCopy(ac *files.Action, rep *report) *files.ActionResponse {
 	source, target, err := isDirectory(ac.Source, ac.Target)
 	if err != nil {
-		return responde(err.Error(), true)
+		return failure(err.Error())
 	}
+	rep.measure(source)
+	copyPath(source, destination(source, target), rep)
+	return rep.response("Copied " + shares.VirtualPath(ac.Source))
+}
 
-	script := "bash -c 'mv " + source + " " + target + "'"
-	os.WriteFile("script.sh", []byte(script), 0777)
-	cmd := exec.Command("bash", "-c", "./script.sh")
-	out, err := cmd.Output()
+func doCut(ac *files.Action, rep *report) *files.ActionResponse {
+	source, target, err := isDirectory(ac.Source, ac.Target)
 	if err != nil {
-		return responde(err.Error(), true)
+		return failure(err.Error())
+	}
+	if shares.IsShareRoot(shares.VirtualPath(ac.Source)) {
+		return failure("Cannot move share root '" + shares.VirtualPath(ac.Source) + "'")
 	}
-	return responde(string(out), false)
+	rep.measure(source)
+	movePath(source, destination(source, target), rep)
+	return rep.response("Moved " + shares.VirtualPath(ac.Source))
 }
 
-func doDelete(ac *files.Action) ifs.IElements {
-	sourcePath := ac.Source.Path + "/" + ac.Source.Name
-	if strings.HasSuffix(sourcePath, "//") {
-		sourcePath = sourcePath[1:]
+func doDelete(ac *files.Action, rep *report) *files.ActionResponse {
+	if ac.Source == nil {
+		return failure("source is nil")
+	}
+	if shares.IsRoot(shares.VirtualPath(ac.Source)) || shares.IsShareRoot(shares.VirtualPath(ac.Source)) {
+		return failure("Cannot delete share root '" + shares.VirtualPath(ac.Source) + "'")
+	}
+	sourcePath, err := shares.ResolveFile(ac.Source)
+	if err != nil {
+		return failure(err.Error())
+	}
+	rep.measure(sourcePath)
+	if !trash.Enabled() || ac.Permanent {
+		removePath(sourcePath, rep)
+		return rep.response("Deleted " + shares.VirtualPath(ac.Source))
 	}
 
-	sourcePath = "\"" + sourcePath + "\""
-
-	script := "bash -c 'rm -rf " + sourcePath + "'"
-	os.WriteFile("script.sh", []byte(script), 0777)
-	cmd := exec.Command("bash", "-c", "./script.sh")
-	out, err := cmd.Output()
+	info, err := os.Lstat(sourcePath)
+	if err != nil {
+		return failure(err.Error())
+	}
+	size, _ := rep.treeSize(sourcePath)
+	item, trashPath, err := trash.Add(shares.VirtualPath(ac.Source), info.IsDir(), size, rep.user)
 	if err != nil {
-		return responde(err.Error(), true)
+		return failure(err.Error())
+	}
+	movePath(sourcePath, trashPath, rep)
+	// Keep whatever made it to the trash, so a partial move loses nothing
+	if _, err = os.Lstat(trashPath); err != nil {
+		share, _, _ := shares.Split(item.Path)
+		trash.Forget(share, item.Id)
 	}
-	return responde(string(out), false)
+	return rep.response("Moved " + shares.VirtualPath(ac.Source) + " to the trash")
 }
 
-func doRename(ac *files.Action) ifs.IElements {
-	source, target, err := isDirectory(ac.Source, ac.Target)
+// trashShares returns the share of the source, or all the shares when there
+// is no source or it is the top level.
+func trashShares(ac *files.Action) ([]*shares.Share, error) {
+	if ac.Source == nil || shares.IsRoot(shares.VirtualPath(ac.Source)) {
+		return shares.List(), nil
+	}
+	share, _, err := shares.Split(shares.VirtualPath(ac.Source))
 	if err != nil {
-		return responde(err.Error(), true)
+		return nil, err
 	}
+	return []*shares.Share{share}, nil
+}
 
-	script := "bash -c 'mv " + source + " " + target + "'"
-	os.WriteFile("script.sh", []byte(script), 0777)
-	cmd := exec.Command("bash", "-c", "./script.sh")
-	out, err := cmd.Output()
+// doListTrash lists the trash items the user deleted.
+func doListTrash(ac *files.Action, user string) *files.ActionResponse {
+	list, err := trashShares(ac)
 	if err != nil {
-		return responde(err.Error(), true)
+		return failure(err.Error())
+	}
+	resp := &files.ActionResponse{Trash: make([]*files.TrashItem, 0)}
+	for _, share := range list {
+		items, err := trash.List(share)
+		if err != nil {
+			return failure(err.Error())
+		}
+		for _, item := range items {
+			if item.User != user {
+				continue
+			}
+			resp.Trash = append(resp.Trash, item)
+			resp.TrashSize += item.Size
+		}
 	}
-	return responde(string(out), false)
+	resp.Msg = strconv.Itoa(len(resp.Trash)) + " items in the trash"
+	return resp
 }
 
-func doNewFolder(ac *files.Action) ifs.IElements {
-	sourcePath := ac.Source.Path + "/" + ac.Source.Name
-	if strings.HasSuffix(sourcePath, "//") {
-		sourcePath = sourcePath[1:]
+// doRestore moves the trash items of the user back to their original path,
+// applying the conflict policy if something was created there in the meantime.
+func doRestore(ac *files.Action, rep *report) *files.ActionResponse {
+	if len(ac.TrashIds) == 0 {
+		return failure("No trash items to restore")
 	}
-	sourcePath = "\"" + sourcePath + "\""
+	for _, id := range ac.TrashIds {
+		if rep.stopped() {
+			break
+		}
+		share, item, err := trash.Find(id, rep.user)
+		if err != nil {
+			rep.fail(id, err)
+			continue
+		}
+		target, err := shares.Resolve(item.Path)
+		if err != nil {
+			rep.fail(item.Path, err)
+			continue
+		}
+		err = os.MkdirAll(filepath.Dir(target), 0755)
+		if err != nil {
+			rep.add(filepath.Dir(target), err)
+			continue
+		}
+		source := trash.Path(share, id)
+		rep.measure(source)
+		movePath(source, target, rep)
+		if _, err = os.Lstat(source); os.IsNotExist(err) {
+			trash.Forget(share, id)
+		}
+	}
+	return rep.response("Restored " + strconv.Itoa(len(ac.TrashIds)) + " items")
+}
+
+// doPurge permanently removes the trash items of the user, or all of them in
+// the trash of the source share when no items are given.
+func doPurge(ac *files.Action, rep *report) *files.ActionResponse {
+	type purged struct {
+		share *shares.Share
+		id    string
+	}
+	list := make([]purged, 0)
+	if len(ac.TrashIds) > 0 {
+		for _, id := range ac.TrashIds {
+			share, _, err := trash.Find(id, rep.user)
+			if err != nil {
+				rep.fail(id, err)
+				continue
+			}
+			list = append(list, purged{share, id})
+		}
+	} else {
+		all, err := trashShares(ac)
+		if err != nil {
+			return failure(err.Error())
+		}
+		for _, share := range all {
+			items, err := trash.List(share)
+			if err != nil {
+				rep.fail(share.Name, err)
+				continue
+			}
+			for _, item := range items {
+				if item.User == rep.user {
+					list = append(list, purged{share, item.Id})
+				}
+			}
+		}
+	}
+	for _, p := range list {
+		rep.measure(trash.Path(p.share, p.id))
+	}
+	for _, p := range list {
+		if rep.stopped() {
+			break
+		}
+		itemRep := &report{job: rep.job, measured: true}
+		removePath(trash.Path(p.share, p.id), itemRep)
+		if !itemRep.failed() {
+			trash.Forget(p.share, p.id)
+		}
+		rep.merge(itemRep)
+	}
+	return rep.response("Purged " + strconv.Itoa(len(list)) + " items")
+}
+
+func doRename(ac *files.Action, rep *report) *files.ActionResponse {
+	source, target, err := isDirectory(ac.Source, ac.Target)
+	if err != nil {
+		return failure(err.Error())
+	}
+	if shares.IsShareRoot(shares.VirtualPath(ac.Source)) {
+		return failure("Cannot rename share root '" + shares.VirtualPath(ac.Source) + "'")
+	}
+	movePath(source, target, rep)
+	return rep.response("Renamed " + shares.VirtualPath(ac.Source))
+}
 
-	script := "bash -c 'mkdir -p " + sourcePath + "'"
-	os.WriteFile("script.sh", []byte(script), 0777)
-	cmd := exec.Command("bash", "-c", "./script.sh")
-	out, err := cmd.Output()
+func doNewFolder(ac *files.Action, rep *report) *files.ActionResponse {
+	if ac.Source == nil {
+		return failure("source is nil")
+	}
+	sourcePath, err := shares.ResolveFile(ac.Source)
 	if err != nil {
-		return responde(err.Error(), true)
+		return failure(err.Error())
 	}
-	return responde(string(out), false)
+	makeDir(sourcePath, rep)
+	return rep.response("Created " + shares.VirtualPath(ac.Source))
 }
 
 func (this *ActionService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
@@ -247,55 +550,159 @@ func (this *ActionService) WebService() ifs.IWebService {
 	return this.sla.WebService()
 }
 
-// DownloadHandler handles file download requests
+// DownloadHandler handles file download requests. It supports single and
+// multi range requests, If-Range, ETag/If-None-Match and If-Modified-Since,
+// the "disposition=inline" query parameter lets the browser display or play
+// the file instead of saving it. A directory, or several "path" parameters,
+// are streamed as a zip or tar.gz archive according to the "format" parameter.
 func DownloadHandler(w http.ResponseWriter, r *http.Request, resources ifs.IResources) {
 	// Extract path from query parameter
-	filePath := r.URL.Query().Get("path")
-	if filePath == "" {
+	paths := r.URL.Query()["path"]
+	if len(paths) == 0 || paths[0] == "" {
 		http.Error(w, "Missing path parameter", http.StatusBadRequest)
 		return
 	}
+	if len(paths) > 1 {
+		downloadArchive(w, r, paths, resources)
+		return
+	}
+	filePath := paths[0]
+	if archivePath, inner, ok := archives.Split(filePath); ok && inner != "" {
+		downloadMember(w, r, archivePath, inner, resources)
+		return
+	}
 
-	// Clean the path to prevent path traversal attacks
-	cleanPath := filepath.Clean(filePath)
+	// Resolve the path inside its share to prevent path traversal attacks
+	cleanPath, err := shares.Resolve(filePath)
+	if err != nil {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
 
-	// Check if file exists and is not a directory
-	fileInfo, err := os.Stat(cleanPath)
+	// Open the file
+	file, err := os.Open(cleanPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			http.Error(w, "File not found", http.StatusNotFound)
 		} else {
-			http.Error(w, "Error accessing file", http.StatusInternalServerError)
+			http.Error(w, "Error opening file", http.StatusInternalServerError)
 		}
 		return
 	}
+	defer file.Close()
 
-	if fileInfo.IsDir() {
-		http.Error(w, "Cannot download a directory", http.StatusBadRequest)
+	// Check the file is not a directory, using the opened file so the
+	// validators match the content being served
+	fileInfo, err := file.Stat()
+	if err != nil {
+		http.Error(w, "Error accessing file", http.StatusInternalServerError)
 		return
 	}
 
-	// Open the file
-	file, err := os.Open(cleanPath)
-	if err != nil {
-		http.Error(w, "Error opening file", http.StatusInternalServerError)
+	if fileInfo.IsDir() {
+		downloadArchive(w, r, paths, resources)
 		return
 	}
-	defer file.Close()
 
 	// Set headers for download
 	fileName := filepath.Base(cleanPath)
+	disposition := "attachment"
+	if r.URL.Query().Get("disposition") == "inline" {
+		disposition = "inline"
+	}
 	// Properly encode filename for Content-Disposition header (RFC 5987)
 	// This handles spaces, special characters, and non-ASCII characters
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", encodeRFC5987(fileName)))
-	w.Header().Set("Content-Type", "application/octet-stream")
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", fileInfo.Size()))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename*=UTF-8''%s", disposition, encodeRFC5987(fileName)))
+	w.Header().Set("Content-Type", contentType(cleanPath, disposition))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", etag(fileInfo))
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	// ServeContent evaluates the conditional headers against the ETag and
+	// the modification time, and serves the requested ranges
+	http.ServeContent(w, r, fileName, fileInfo.ModTime(), file)
+}
 
-	// Stream file to response
-	_, err = io.Copy(w, file)
+// downloadMember streams a file from inside an archive. Its content is
+// decompressed on the fly, so ranges are not supported.
+func downloadMember(w http.ResponseWriter, r *http.Request, archivePath, inner string, resources ifs.IResources) {
+	reader, info, err := archives.Open(archivePath, inner)
+	if err != nil {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+	defer reader.Close()
+	archiveInfo, err := os.Stat(archivePath)
+	if err != nil {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+	tag := memberEtag(archiveInfo, inner)
+	if r.Header.Get("If-None-Match") == tag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	disposition := "attachment"
+	if r.URL.Query().Get("disposition") == "inline" {
+		disposition = "inline"
+	}
+	t := files2.DetectType(info.Name(), false)
+	if t == "" {
+		t = "application/octet-stream"
+	} else if disposition == "inline" && activeContent(t) {
+		t = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename*=UTF-8''%s", disposition, encodeRFC5987(info.Name())))
+	w.Header().Set("Content-Type", t)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+	w.Header().Set("Last-Modified", info.ModTime().UTC().Format(http.TimeFormat))
+	w.Header().Set("ETag", tag)
+	w.Header().Set("Accept-Ranges", "none")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, err = io.Copy(w, io.LimitReader(reader, info.Size()))
 	if err != nil {
-		resources.Logger().Error("Error streaming file: ", err)
+		resources.Logger().Error("Error streaming archive member: ", err)
+	}
+}
+
+// contentType returns the detected type of the file. Content the browser
+// would run in the origin of the server is only displayed inline as text.
+func contentType(realPath, disposition string) string {
+	t := files2.DetectType(realPath, true)
+	if t == "" {
+		return "application/octet-stream"
+	}
+	if disposition == "inline" && activeContent(t) {
+		return "text/plain; charset=utf-8"
+	}
+	return t
+}
+
+func activeContent(t string) bool {
+	t, _, _ = strings.Cut(t, ";")
+	switch strings.TrimSpace(strings.ToLower(t)) {
+	case "text/html", "application/xhtml+xml", "image/svg+xml", "text/xml", "application/xml",
+		"text/javascript", "application/javascript":
+		return true
 	}
+	return false
+}
+
+// etag is a strong validator of the file content based on its size and
+// modification time.
+func etag(info os.FileInfo) string {
+	return "\"" + strconv.FormatInt(info.Size(), 16) + "-" + strconv.FormatInt(info.ModTime().UnixNano(), 16) + "\""
+}
+
+// memberEtag returns the entity tag of the member of the archive, a hash of
+// the tag of the archive and the member path.
+func memberEtag(archiveInfo os.FileInfo, inner string) string {
+	sum := sha256.Sum256([]byte(etag(archiveInfo) + "/" + inner))
+	return "\"" + hex.EncodeToString(sum[:16]) + "\""
 }
 
 // encodeRFC5987 encodes a string according to RFC 5987
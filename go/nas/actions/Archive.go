@@ -0,0 +1,400 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package actions
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/saichler/l8nasfile/go/nas/jobs"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8types/go/ifs"
+)
+
+const (
+	FormatZip    = "zip"
+	FormatTar    = "tar"
+	FormatTarGz  = "tar.gz"
+	FormatTarZst = "tar.zst"
+)
+
+// archiveFormat returns the format of an archive by its file name.
+func archiveFormat(name string) (string, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return FormatZip, nil
+	case strings.HasSuffix(lower, ".tar"):
+		return FormatTar, nil
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return FormatTarGz, nil
+	case strings.HasSuffix(lower, ".tar.zst"), strings.HasSuffix(lower, ".tzst"):
+		return FormatTarZst, nil
+	}
+	return "", errors.New("Unsupported archive format of '" + name + "'")
+}
+
+// archiveContentType returns the content type of the format, or an empty
+// string for an unsupported format.
+func archiveContentType(format string) string {
+	switch format {
+	case FormatZip:
+		return "application/zip"
+	case FormatTar:
+		return "application/x-tar"
+	case FormatTarGz:
+		return "application/gzip"
+	case FormatTarZst:
+		return "application/zstd"
+	}
+	return ""
+}
+
+// archiveWriter writes the entries of an archive, with names relative to the
+// archive root and slash separated.
+type archiveWriter interface {
+	addDir(name string, info os.FileInfo) error
+	addFile(name string, info os.FileInfo, content io.Reader) error
+	Close() error
+}
+
+func newArchiveWriter(w io.Writer, format string) (archiveWriter, error) {
+	switch format {
+	case FormatZip:
+		return &zipWriter{zw: zip.NewWriter(w)}, nil
+	case FormatTar:
+		return &tarWriter{tw: tar.NewWriter(w)}, nil
+	case FormatTarGz:
+		gz := gzip.NewWriter(w)
+		return &tarWriter{tw: tar.NewWriter(gz), closer: gz}, nil
+	case FormatTarZst:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		return &tarWriter{tw: tar.NewWriter(zw), closer: zw}, nil
+	}
+	return nil, errors.New("Unsupported archive format '" + format + "'")
+}
+
+type zipWriter struct {
+	zw *zip.Writer
+}
+
+func (this *zipWriter) addDir(name string, info os.FileInfo) error {
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name + "/"
+	_, err = this.zw.CreateHeader(header)
+	return err
+}
+
+func (this *zipWriter) addFile(name string, info os.FileInfo, content io.Reader) error {
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	writer, err := this.zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, content)
+	return err
+}
+
+func (this *zipWriter) Close() error {
+	return this.zw.Close()
+}
+
+type tarWriter struct {
+	tw     *tar.Writer
+	closer io.Closer
+}
+
+func (this *tarWriter) addDir(name string, info os.FileInfo) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name + "/"
+	header.Uname, header.Gname = "", ""
+	return this.tw.WriteHeader(header)
+}
+
+func (this *tarWriter) addFile(name string, info os.FileInfo, content io.Reader) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Uname, header.Gname = "", ""
+	err = this.tw.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+	// Never write more than the header size, the file may grow while archiving
+	_, err = io.Copy(this.tw, io.LimitReader(content, info.Size()))
+	return err
+}
+
+func (this *tarWriter) Close() error {
+	err := this.tw.Close()
+	if this.closer != nil {
+		closeErr := this.closer.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// addTree adds the file or directory at realPath to the archive under name,
+// keeping the relative structure and the modification times. Symbolic links
+// and special files are skipped, as is the trash of a share, failed entries
+// are reported to rep and skipped as well, so a single unreadable file does
+// not break the stream. The progress
+// is reported to the job of rep, if any, and a cancelled job stops the walk.
+func addTree(aw archiveWriter, realPath, name string, rep *report) error {
+	return filepath.WalkDir(realPath, func(path string, d fs.DirEntry, err error) error {
+		if rep.stopped() {
+			return jobs.ErrCancelled
+		}
+		if err != nil {
+			rep.add(path, err)
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(realPath, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && trash.IsTrash(path) {
+			return filepath.SkipDir
+		}
+		entryName := filepath.ToSlash(filepath.Join(name, rel))
+		info, err := d.Info()
+		if err != nil {
+			rep.add(path, err)
+			return nil
+		}
+		defer rep.progress(0, 1)
+		if info.IsDir() {
+			return aw.addDir(entryName, info)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			rep.add(path, err)
+			return nil
+		}
+		defer file.Close()
+		return aw.addFile(entryName, info, &progressReader{reader: file, rep: rep})
+	})
+}
+
+// downloadArchive streams the files and directories of the virtual paths as
+// an archive, without staging it on disk.
+func downloadArchive(w http.ResponseWriter, r *http.Request, paths []string, resources ifs.IResources) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = FormatZip
+	}
+	contentType := archiveContentType(format)
+	if contentType == "" {
+		http.Error(w, "Unsupported archive format '"+format+"'", http.StatusBadRequest)
+		return
+	}
+
+	realPaths := make([]string, len(paths))
+	for i, path := range paths {
+		realPath, err := shares.Resolve(path)
+		if err != nil || shares.IsRoot(path) {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+		_, err = os.Lstat(realPath)
+		if err != nil {
+			http.Error(w, "File not found", http.StatusNotFound)
+			return
+		}
+		realPaths[i] = realPath
+	}
+
+	archiveName := "download." + format
+	if len(paths) == 1 {
+		archiveName = filepath.Base(shares.Clean(paths[0])) + "." + format
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", encodeRFC5987(archiveName)))
+	w.Header().Set("Content-Type", contentType)
+
+	aw, err := newArchiveWriter(w, format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rep := &report{}
+	names := entryNames(paths)
+	for i, realPath := range realPaths {
+		err = addTree(aw, realPath, names[i], rep)
+		if err != nil {
+			break
+		}
+	}
+	// The writer is closed on errors too, so nothing writes to the response
+	// once the handler returned
+	closeErr := aw.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		resources.Logger().Error("Error streaming archive: ", err)
+	}
+	for _, result := range rep.results {
+		resources.Logger().Error("Skipped archive entry ", result.Msg)
+	}
+}
+
+// entryNames returns the top level entry names of the archived virtual paths,
+// their base names made unique by adding a " (n)" suffix before the extension,
+// so "/a/x" and "/b/x" are archived as "x" and "x (1)" and a share root is
+// archived under the name of the share.
+func entryNames(paths []string) []string {
+	names := make([]string, len(paths))
+	used := make(map[string]bool)
+	for i, path := range paths {
+		name := filepath.Base(shares.Clean(path))
+		ext := filepath.Ext(name)
+		if ext == name {
+			ext = ""
+		}
+		base := strings.TrimSuffix(name, ext)
+		for n := 1; used[name]; n++ {
+			name = base + " (" + strconv.Itoa(n) + ")" + ext
+		}
+		used[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// doCompress packs the sources into the archive at the target, its format is
+// given by the target name. The archive is written to a temporary file next
+// to the target and only renamed into place once complete.
+func doCompress(ac *files.Action, rep *report) *files.ActionResponse {
+	sources := ac.Sources
+	if len(sources) == 0 && ac.Source != nil {
+		sources = []*files.File{ac.Source}
+	}
+	if len(sources) == 0 || ac.Target == nil {
+		return failure("No sources or target to compress")
+	}
+	format, err := archiveFormat(ac.Target.Name)
+	if err != nil {
+		return failure(err.Error())
+	}
+	targetPath, err := shares.ResolveFile(ac.Target)
+	if err != nil {
+		return failure(err.Error())
+	}
+	sourcePaths := make([]string, len(sources))
+	for i, source := range sources {
+		if source == nil || shares.IsRoot(shares.VirtualPath(source)) {
+			return failure("Invalid source to compress")
+		}
+		sourcePaths[i], err = shares.ResolveFile(source)
+		if err != nil {
+			return failure(err.Error())
+		}
+		if _, err = os.Lstat(sourcePaths[i]); err != nil {
+			return failure("Source '" + shares.VirtualPath(source) + "' does not exist")
+		}
+		if shares.IsWithin(filepath.Dir(targetPath), sourcePaths[i]) {
+			return failure("Cannot create the archive inside the source '" + shares.VirtualPath(source) + "'")
+		}
+	}
+	for _, sourcePath := range sourcePaths {
+		rep.measure(sourcePath)
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(targetPath), "."+filepath.Base(targetPath)+".partial-*")
+	if err != nil {
+		return failure(err.Error())
+	}
+	defer os.Remove(temp.Name())
+	aw, err := newArchiveWriter(temp, format)
+	if err != nil {
+		temp.Close()
+		return failure(err.Error())
+	}
+	virtualPaths := make([]string, len(sources))
+	for i, source := range sources {
+		virtualPaths[i] = shares.VirtualPath(source)
+	}
+	names := entryNames(virtualPaths)
+	for i, sourcePath := range sourcePaths {
+		err = addTree(aw, sourcePath, names[i], rep)
+		if err != nil {
+			break
+		}
+	}
+	closeErr := aw.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = temp.Sync()
+	}
+	closeErr = temp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		rep.add(targetPath, err)
+		return rep.response("")
+	}
+
+	info, err := os.Lstat(temp.Name())
+	if err != nil {
+		rep.add(targetPath, err)
+		return rep.response("")
+	}
+	targetPath, ok := resolveConflict(temp.Name(), targetPath, info, rep)
+	if ok {
+		rep.add(targetPath, os.Rename(temp.Name(), targetPath))
+	}
+	return rep.response("Compressed " + strconv.Itoa(len(sources)) + " items to " + virtualOf(targetPath))
+}
@@ -0,0 +1,465 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package actions
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+var archiveMtx = &sync.Mutex{}
+var archiveLimits = config.Default().Archive
+
+// ConfigureArchives sets the limits of the extracted archives.
+func ConfigureArchives(cfg *config.ArchiveConfig) {
+	archiveMtx.Lock()
+	defer archiveMtx.Unlock()
+	archiveLimits = cfg
+}
+
+// extractor writes the entries of an archive under root, which is a real
+// path without symbolic links. Every entry is checked to stay under root and
+// outside the reserved directories of the share, whatever its name or the
+// links extracted before it.
+type extractor struct {
+	root       string
+	archive    string
+	rep        *report
+	maxSize    int64
+	maxEntries int64
+	written    int64
+	entries    int64
+	dirs       map[string]time.Time
+	created    map[string]bool
+}
+
+// doExtract unpacks the source archive into the target directory, which is
+// created if needed. The format is detected from the content of the archive.
+func doExtract(ac *files.Action, rep *report) *files.ActionResponse {
+	if ac.Source == nil || ac.Target == nil {
+		return failure("source or target are nil")
+	}
+	archivePath, err := shares.ResolveFile(ac.Source)
+	if err != nil {
+		return failure(err.Error())
+	}
+	info, err := os.Stat(archivePath)
+	if err != nil || !info.Mode().IsRegular() {
+		return failure("Archive '" + shares.VirtualPath(ac.Source) + "' does not exist")
+	}
+	if shares.IsRoot(shares.VirtualPath(ac.Target)) {
+		return failure("Path '/' is not inside a share")
+	}
+	targetPath, err := shares.ResolveFile(ac.Target)
+	if err != nil {
+		return failure(err.Error())
+	}
+	err = os.MkdirAll(targetPath, 0755)
+	if err != nil {
+		rep.add(targetPath, err)
+		return rep.response("")
+	}
+	root, err := filepath.EvalSymlinks(targetPath)
+	if err != nil {
+		rep.add(targetPath, err)
+		return rep.response("")
+	}
+
+	archiveMtx.Lock()
+	limits := archiveLimits
+	archiveMtx.Unlock()
+	this := &extractor{root: root, archive: archivePath, rep: rep, maxSize: limits.MaxSize,
+		maxEntries: limits.MaxEntries, dirs: make(map[string]time.Time), created: make(map[string]bool)}
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		rep.add(archivePath, err)
+		return rep.response("")
+	}
+	defer file.Close()
+	header := make([]byte, 512)
+	n, _ := io.ReadFull(file, header)
+	header = header[:n]
+	switch {
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")) || bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		err = this.zip(file, info.Size())
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		err = this.compressedTar(file, info.Size(), FormatTarGz)
+	case bytes.HasPrefix(header, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		err = this.compressedTar(file, info.Size(), FormatTarZst)
+	case len(header) > 262 && string(header[257:262]) == "ustar":
+		err = this.compressedTar(file, info.Size(), FormatTar)
+	default:
+		err = errors.New("Unsupported archive format of '" + shares.VirtualPath(ac.Source) + "'")
+	}
+	// Directories are modified by their content, so their times are set last
+	for dir, modified := range this.dirs {
+		os.Chtimes(dir, modified, modified)
+	}
+	if err != nil {
+		rep.add(archivePath, err)
+	}
+	return rep.response("Extracted " + strconv.FormatInt(this.entries, 10) + " entries of " +
+		shares.VirtualPath(ac.Source) + " to " + shares.VirtualPath(ac.Target))
+}
+
+// zip extracts a zip archive, rejecting it right away if its declared
+// content exceeds the limits. The limits are also enforced while writing,
+// as the declared sizes cannot be trusted.
+func (this *extractor) zip(file *os.File, size int64) error {
+	zr, err := zip.NewReader(file, size)
+	if err != nil {
+		return err
+	}
+	var total uint64
+	for _, f := range zr.File {
+		total += f.UncompressedSize64
+	}
+	if this.maxEntries > 0 && int64(len(zr.File)) > this.maxEntries {
+		return this.tooManyEntries()
+	}
+	if this.maxSize > 0 && total > uint64(this.maxSize) {
+		return this.tooLarge()
+	}
+	if this.rep.job != nil {
+		this.rep.job.AddTotals(int64(total), int64(len(zr.File)))
+	}
+	for _, f := range zr.File {
+		if this.rep.stopped() {
+			return nil
+		}
+		info := f.FileInfo()
+		err = this.entry(f.Name, info, "", "", func() (io.ReadCloser, error) {
+			reader, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			return &progressCloser{progressReader: progressReader{reader: reader, rep: this.rep}, closer: reader}, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compressedTar extracts a tar archive, optionally compressed. The progress
+// is measured in bytes of the archive file, as the tar content is unknown
+// until it was read.
+func (this *extractor) compressedTar(file *os.File, size int64, format string) error {
+	_, err := file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	if this.rep.job != nil {
+		this.rep.job.AddTotals(size, 0)
+	}
+	var reader io.Reader = bufio.NewReader(&progressReader{reader: file, rep: this.rep})
+	switch format {
+	case FormatTarGz:
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	case FormatTarZst:
+		zr, err := zstd.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		reader = zr
+	}
+	tr := tar.NewReader(reader)
+	for {
+		if this.rep.stopped() {
+			return nil
+		}
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = this.entry(header.Name, header.FileInfo(), header.Linkname, tarLinkType(header), func() (io.ReadCloser, error) {
+			return io.NopCloser(tr), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func tarLinkType(header *tar.Header) string {
+	switch header.Typeflag {
+	case tar.TypeSymlink:
+		return "symlink"
+	case tar.TypeLink:
+		return "hardlink"
+	}
+	return ""
+}
+
+// entry extracts a single entry. Entries that are invalid or that escape the
+// root are reported and skipped, while exceeding the limits aborts the whole
+// extraction by returning an error.
+func (this *extractor) entry(name string, info os.FileInfo, linkName, linkType string,
+	open func() (io.ReadCloser, error)) error {
+	this.entries++
+	if this.maxEntries > 0 && this.entries > this.maxEntries {
+		return this.tooManyEntries()
+	}
+	defer this.rep.progress(0, 1)
+	rel, err := this.clean(name)
+	if err != nil {
+		this.rep.fail(name, err)
+		return nil
+	}
+	if rel == "." {
+		return nil
+	}
+	if shares.IsReserved(filepath.Join(this.root, rel)) {
+		this.rep.fail(name, errors.New("Entry '"+name+"' is reserved"))
+		return nil
+	}
+	if info.IsDir() {
+		dir, err := this.dir(rel)
+		if err != nil {
+			this.rep.fail(name, err)
+			return nil
+		}
+		// The existing directories keep their permissions and times
+		if this.created[dir] {
+			os.Chmod(dir, info.Mode().Perm()|0700)
+			this.dirs[dir] = info.ModTime()
+		}
+		return nil
+	}
+	parent, err := this.dir(filepath.Dir(rel))
+	if err != nil {
+		this.rep.fail(name, err)
+		return nil
+	}
+	path := filepath.Join(parent, filepath.Base(rel))
+	dst, ok := resolveConflict(filepath.Join(this.archive, rel), path, info, this.rep)
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case linkType == "symlink" || info.Mode()&os.ModeSymlink != 0:
+		target := linkName
+		if target == "" {
+			target, err = readAll(open)
+			if err != nil {
+				this.rep.add(dst, err)
+				return nil
+			}
+		}
+		target, err = this.linkTarget(parent, target)
+		if err != nil {
+			this.rep.fail(name, err)
+			return nil
+		}
+		this.rep.add(dst, os.Symlink(target, dst))
+	case linkType == "hardlink":
+		linkRel, err := this.clean(linkName)
+		if err != nil {
+			this.rep.fail(name, err)
+			return nil
+		}
+		linkParent, err := this.dir(filepath.Dir(linkRel))
+		if err != nil {
+			this.rep.fail(name, err)
+			return nil
+		}
+		source := filepath.Join(linkParent, filepath.Base(linkRel))
+		sourceInfo, err := os.Lstat(source)
+		if err != nil || !sourceInfo.Mode().IsRegular() {
+			this.rep.fail(name, errors.New("Link target '"+linkName+"' is not an extracted file"))
+			return nil
+		}
+		this.rep.add(dst, os.Link(source, dst))
+	case info.Mode().IsRegular():
+		return this.file(dst, info, open)
+	default:
+		this.rep.done(dst, "skipped unsupported entry")
+	}
+	return nil
+}
+
+// file writes the content of a regular file, never through an existing link.
+func (this *extractor) file(dst string, info os.FileInfo, open func() (io.ReadCloser, error)) error {
+	content, err := open()
+	if err != nil {
+		this.rep.add(dst, err)
+		return nil
+	}
+	defer content.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		this.rep.add(dst, err)
+		return nil
+	}
+	var reader io.Reader = content
+	if this.maxSize > 0 {
+		reader = io.LimitReader(content, this.maxSize-this.written+1)
+	}
+	n, err := io.Copy(out, reader)
+	this.written += n
+	closeErr := out.Close()
+	if this.maxSize > 0 && this.written > this.maxSize {
+		os.Remove(dst)
+		return this.tooLarge()
+	}
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dst)
+		this.rep.add(dst, err)
+		return nil
+	}
+	os.Chtimes(dst, info.ModTime(), info.ModTime())
+	return nil
+}
+
+// clean returns the entry name as a clean relative path, rejecting the
+// absolute names and the names climbing out of the root (zip slip).
+func (this *extractor) clean(name string) (string, error) {
+	name = filepath.FromSlash(strings.ReplaceAll(name, "\\", "/"))
+	if filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", errors.New("Entry '" + name + "' has an absolute path")
+	}
+	rel := filepath.Clean(name)
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("Entry '" + name + "' escapes the target directory")
+	}
+	return rel, nil
+}
+
+// dir returns the real path of the relative directory, creating the missing
+// directories one by one. Existing links are followed only when they stay
+// under the root and outside the reserved directories, so an extracted link
+// cannot redirect the later entries.
+func (this *extractor) dir(rel string) (string, error) {
+	current := this.root
+	if rel == "." {
+		return current, nil
+	}
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		next := filepath.Join(current, part)
+		info, err := os.Lstat(next)
+		if os.IsNotExist(err) {
+			err = os.Mkdir(next, 0755)
+			if err != nil {
+				return "", err
+			}
+			this.created[next] = true
+			current = next
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			resolved, err := filepath.EvalSymlinks(next)
+			if err != nil {
+				return "", err
+			}
+			if !shares.IsWithin(resolved, this.root) {
+				return "", errors.New("Directory '" + rel + "' escapes the target directory")
+			}
+			if shares.IsReserved(resolved) {
+				return "", errors.New("Directory '" + rel + "' is reserved")
+			}
+			next = resolved
+			info, err = os.Stat(next)
+			if err != nil {
+				return "", err
+			}
+		}
+		if !info.IsDir() {
+			return "", errors.New("Path '" + rel + "' is not a directory")
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// linkTarget validates the target of a symbolic link extracted in parent. It
+// must be relative and stay under the root, it is returned clean so the
+// kernel resolves it the same way it was checked.
+func (this *extractor) linkTarget(parent, target string) (string, error) {
+	if target == "" || filepath.IsAbs(target) {
+		return "", errors.New("Link target '" + target + "' is not relative")
+	}
+	target = filepath.Clean(filepath.FromSlash(target))
+	if !shares.IsWithin(filepath.Join(parent, target), this.root) {
+		return "", errors.New("Link target '" + target + "' escapes the target directory")
+	}
+	if shares.IsReserved(filepath.Join(parent, target)) {
+		return "", errors.New("Link target '" + target + "' is reserved")
+	}
+	return target, nil
+}
+
+func (this *extractor) tooManyEntries() error {
+	return errors.New("Archive has more than " + strconv.FormatInt(this.maxEntries, 10) + " entries")
+}
+
+func (this *extractor) tooLarge() error {
+	return errors.New("Archive expands to more than " + strconv.FormatInt(this.maxSize, 10) + " bytes")
+}
+
+// readAll reads the content of a small entry, the target of a zip link.
+func readAll(open func() (io.ReadCloser, error)) (string, error) {
+	reader, err := open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, 4096))
+	return string(data), err
+}
+
+// progressCloser is a progressReader closing the underlying reader.
+type progressCloser struct {
+	progressReader
+	closer io.Closer
+}
+
+func (this *progressCloser) Close() error {
+	return this.closer.Close()
+}
@@ -0,0 +1,457 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package actions
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/saichler/l8nasfile/go/nas/jobs"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+// report collects the per-entry results of a file operation so a single
+// bad entry does not abort the whole tree. When the operation runs as a job,
+// it also reports the progress to the job and stops once it is cancelled.
+type report struct {
+	results   []*files.ActionResult
+	errors    int
+	job       *jobs.Job
+	cancelled bool
+	// measured is set when the job totals were already measured
+	measured bool
+	// policy resolves the entries that already exist at the target
+	policy    files.ConflictPolicy
+	conflicts []*files.ActionConflict
+	// user is the authenticated user running the action
+	user string
+}
+
+// add records err for the entry at the real path, both are reported with the
+// virtual path so the share roots are not exposed to the client.
+func (this *report) add(path string, err error) {
+	if err == nil {
+		return
+	}
+	if errors.Is(err, jobs.ErrCancelled) {
+		this.cancelled = true
+		return
+	}
+	virtualPath := virtualOf(path)
+	msg := err.Error()
+	pathErr := &fs.PathError{}
+	linkErr := &os.LinkError{}
+	if errors.As(err, &pathErr) {
+		msg = pathErr.Op + ": " + pathErr.Err.Error()
+	} else if errors.As(err, &linkErr) {
+		msg = linkErr.Op + ": " + linkErr.Err.Error()
+	}
+	this.results = append(this.results, &files.ActionResult{Path: virtualPath, IsError: true, Msg: virtualPath + ": " + msg})
+	this.errors++
+}
+
+// done records a successful entry, used when the client needs to know what
+// happened to every entry and not only to the failed ones.
+func (this *report) done(path, msg string) {
+	virtualPath := virtualOf(path)
+	this.results = append(this.results, &files.ActionResult{Path: virtualPath, Msg: virtualPath + ": " + msg})
+}
+
+// fail records the error of an entry that has no real path, like a trash item.
+func (this *report) fail(name string, err error) {
+	this.results = append(this.results, &files.ActionResult{Path: name, IsError: true, Msg: name + ": " + err.Error()})
+	this.errors++
+}
+
+// conflict records how the conflict between src and the existing dst was resolved.
+func (this *report) conflict(src, dst, resolution string) {
+	this.conflicts = append(this.conflicts, &files.ActionConflict{Source: virtualOf(src), Target: virtualOf(dst),
+		Policy: this.policy, Resolution: resolution})
+}
+
+// merge adds the results of a sub operation to this report.
+func (this *report) merge(other *report) {
+	this.results = append(this.results, other.results...)
+	this.conflicts = append(this.conflicts, other.conflicts...)
+	this.errors += other.errors
+	this.cancelled = this.cancelled || other.cancelled
+}
+
+func (this *report) failed() bool {
+	return this.errors > 0 || this.cancelled
+}
+
+// stopped returns true once the job of the operation was cancelled.
+func (this *report) stopped() bool {
+	if this.job != nil && this.job.Err() != nil {
+		this.cancelled = true
+	}
+	return this.cancelled
+}
+
+// measure adds the size and the number of files under path to the job totals.
+func (this *report) measure(path string) {
+	if this.job == nil || this.measured {
+		return
+	}
+	this.job.AddTotals(this.treeSize(path))
+}
+
+// treeSize returns the size of the regular files and the number of entries under path.
+func (this *report) treeSize(path string) (int64, int64) {
+	var bytes, count int64
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || this.stopped() {
+			return nil
+		}
+		count++
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err == nil {
+				bytes += info.Size()
+			}
+		}
+		return nil
+	})
+	return bytes, count
+}
+
+// progress adds the processed bytes and files to the job.
+func (this *report) progress(bytes, count int64) {
+	if this.job == nil {
+		return
+	}
+	if bytes > 0 {
+		this.job.AddBytes(bytes)
+	}
+	if count > 0 {
+		this.job.AddFiles(count)
+	}
+}
+
+func (this *report) response(okMsg string) *files.ActionResponse {
+	resp := &files.ActionResponse{Results: this.results, Conflicts: this.conflicts}
+	if this.cancelled {
+		resp.IsError = true
+		resp.Msg = jobs.ErrCancelled.Error()
+		return resp
+	}
+	if this.failed() {
+		resp.IsError = true
+		if this.errors == 1 {
+			for _, result := range this.results {
+				if result.IsError {
+					resp.Msg = result.Msg
+				}
+			}
+		} else {
+			resp.Msg = strconv.Itoa(this.errors) + " entries failed"
+		}
+		return resp
+	}
+	resp.Msg = okMsg
+	return resp
+}
+
+func virtualOf(path string) string {
+	virtualPath, err := shares.Virtual(path)
+	if err != nil {
+		return filepath.Base(path)
+	}
+	return virtualPath
+}
+
+// copyPath recursively copies src to dst, preserving modes, modification
+// times and symbolic links.
+func copyPath(src, dst string, rep *report) {
+	info, err := os.Lstat(src)
+	if err != nil {
+		rep.add(src, err)
+		return
+	}
+	if info.IsDir() && shares.IsWithin(dst, src) {
+		rep.add(src, errors.New("Cannot copy a directory into itself"))
+		return
+	}
+	copyEntry(src, dst, info, rep)
+}
+
+func copyEntry(src, dst string, info os.FileInfo, rep *report) {
+	if rep.stopped() {
+		return
+	}
+	defer rep.progress(0, 1)
+	dst, ok := resolveConflict(src, dst, info, rep)
+	if !ok {
+		return
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(src)
+		if err != nil {
+			rep.add(src, err)
+			return
+		}
+		rep.add(dst, os.Symlink(link, dst))
+	case info.IsDir():
+		err := os.Mkdir(dst, info.Mode().Perm())
+		if err != nil && !os.IsExist(err) {
+			rep.add(dst, err)
+			return
+		}
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			rep.add(src, err)
+			return
+		}
+		for _, entry := range entries {
+			entryInfo, err := entry.Info()
+			if err != nil {
+				rep.add(filepath.Join(src, entry.Name()), err)
+				continue
+			}
+			copyEntry(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name()), entryInfo, rep)
+		}
+		os.Chtimes(dst, info.ModTime(), info.ModTime())
+	case info.Mode().IsRegular():
+		rep.add(dst, copyFile(src, dst, info, rep))
+	default:
+		rep.add(src, errors.New("Unsupported file type '"+info.Mode().Type().String()+"'"))
+	}
+}
+
+func copyFile(src, dst string, info os.FileInfo, rep *report) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, &progressReader{reader: in, rep: rep})
+	if err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	err = out.Close()
+	if err != nil {
+		return err
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
+
+// movePath renames src to dst, falling back to copy and delete when the two
+// are on different file systems. A directory moved onto an existing directory
+// is merged into it when the conflict policy allows.
+func movePath(src, dst string, rep *report) {
+	if rep.stopped() {
+		return
+	}
+	info, err := os.Lstat(src)
+	if err != nil {
+		rep.add(src, err)
+		return
+	}
+	if info.IsDir() && dst != src && shares.IsWithin(dst, src) {
+		rep.add(src, errors.New("Cannot move a directory into itself"))
+		return
+	}
+	dst, ok := resolveConflict(src, dst, info, rep)
+	if !ok {
+		rep.progress(rep.treeSize(src))
+		return
+	}
+	if dstInfo, err := os.Lstat(dst); err == nil && dstInfo.IsDir() && info.IsDir() {
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			rep.add(src, err)
+			return
+		}
+		for _, entry := range entries {
+			movePath(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name()), rep)
+		}
+		rep.add(src, os.Remove(src))
+		rep.progress(0, 1)
+		return
+	}
+	err = os.Rename(src, dst)
+	if err == nil {
+		if rep.job != nil {
+			rep.progress(rep.treeSize(dst))
+		}
+		return
+	}
+	if !errors.Is(err, syscall.EXDEV) {
+		rep.add(src, err)
+		return
+	}
+	copyRep := &report{job: rep.job, policy: rep.policy}
+	copyEntry(src, dst, info, copyRep)
+	rep.merge(copyRep)
+	if copyRep.failed() {
+		return
+	}
+	// The files were already counted by the copy
+	removeRep := &report{}
+	removePath(src, removeRep)
+	rep.merge(removeRep)
+}
+
+// resolveConflict applies the conflict policy when dst already exists. It
+// returns the path to write to, which is a new name for keepBoth, and
+// whether to go on with the entry. Directories are merged rather than
+// replaced when overwriting.
+func resolveConflict(src, dst string, info os.FileInfo, rep *report) (string, bool) {
+	dstInfo, err := os.Lstat(dst)
+	if err != nil {
+		return dst, true
+	}
+	if os.SameFile(info, dstInfo) {
+		if rep.policy == files.ConflictPolicy_keepBoth {
+			unique := uniqueName(dst)
+			rep.conflict(src, dst, "kept both as "+virtualOf(unique))
+			return unique, true
+		}
+		rep.add(dst, errors.New("Source and target are the same"))
+		return "", false
+	}
+	merge := info.IsDir() && dstInfo.IsDir()
+	switch rep.policy {
+	case files.ConflictPolicy_skip:
+		rep.conflict(src, dst, "skipped")
+		return "", false
+	case files.ConflictPolicy_keepBoth:
+		unique := uniqueName(dst)
+		rep.conflict(src, dst, "kept both as "+virtualOf(unique))
+		return unique, true
+	case files.ConflictPolicy_overwriteIfNewer:
+		if merge {
+			rep.conflict(src, dst, "merged")
+			return dst, true
+		}
+		if !info.ModTime().After(dstInfo.ModTime()) {
+			rep.conflict(src, dst, "skipped, target is newer")
+			return "", false
+		}
+		if !removeExisting(dst, rep) {
+			return "", false
+		}
+		rep.conflict(src, dst, "overwritten, source is newer")
+		return dst, true
+	case files.ConflictPolicy_overwrite:
+		if merge {
+			rep.conflict(src, dst, "merged")
+			return dst, true
+		}
+		if !removeExisting(dst, rep) {
+			return "", false
+		}
+		rep.conflict(src, dst, "overwritten")
+		return dst, true
+	}
+	rep.conflict(src, dst, "failed")
+	rep.add(dst, errors.New("Target already exists"))
+	return "", false
+}
+
+// removeExisting removes the existing target that is being overwritten,
+// without counting it in the progress of the job.
+func removeExisting(path string, rep *report) bool {
+	removeRep := &report{}
+	removePath(path, removeRep)
+	rep.merge(removeRep)
+	return !removeRep.failed()
+}
+
+// removePath deletes path and everything below it, reporting every entry
+// that could not be removed.
+func removePath(path string, rep *report) {
+	if rep.stopped() {
+		return
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		rep.add(path, err)
+		return
+	}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			rep.add(path, err)
+			return
+		}
+		for _, entry := range entries {
+			removePath(filepath.Join(path, entry.Name()), rep)
+		}
+	}
+	err = os.Remove(path)
+	rep.add(path, err)
+	if err == nil && info.Mode().IsRegular() {
+		rep.progress(info.Size(), 1)
+	} else {
+		rep.progress(0, 1)
+	}
+}
+
+// progressReader reports the bytes read to the job of the operation and fails
+// the read once the job was cancelled.
+type progressReader struct {
+	reader io.Reader
+	rep    *report
+}
+
+func (this *progressReader) Read(p []byte) (int, error) {
+	if this.rep.stopped() {
+		return 0, jobs.ErrCancelled
+	}
+	n, err := this.reader.Read(p)
+	this.rep.progress(int64(n), 0)
+	return n, err
+}
+
+// makeDir creates path including any missing parents.
+func makeDir(path string, rep *report) {
+	rep.add(path, os.MkdirAll(path, 0755))
+}
+
+// uniqueName returns a path that does not exist yet by adding a " (n)"
+// suffix before the extension of path.
+func uniqueName(path string) string {
+	dir := filepath.Dir(path)
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+	if ext == name {
+		ext = ""
+	}
+	base := strings.TrimSuffix(name, ext)
+	for i := 1; ; i++ {
+		candidate := filepath.Join(dir, base+" ("+strconv.Itoa(i)+")"+ext)
+		if _, err := os.Lstat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
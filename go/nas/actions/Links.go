@@ -0,0 +1,104 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package actions
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+// linkPaths resolves the link target, the source of the action, and the new
+// link, the target of the action, both inside their shares.
+func linkPaths(ac *files.Action) (string, string, os.FileInfo, error) {
+	if ac.Source == nil || ac.Target == nil {
+		return "", "", nil, errors.New("source or target are nil")
+	}
+	sourcePath, err := shares.ResolveFile(ac.Source)
+	if err != nil {
+		return "", "", nil, err
+	}
+	info, err := os.Lstat(sourcePath)
+	if err != nil {
+		return "", "", nil, errors.New("Source '" + shares.VirtualPath(ac.Source) + "' does not exist")
+	}
+	if shares.IsShareRoot(shares.VirtualPath(ac.Target)) || shares.IsRoot(shares.VirtualPath(ac.Target)) {
+		return "", "", nil, errors.New("Cannot replace share root '" + shares.VirtualPath(ac.Target) + "'")
+	}
+	linkPath, err := shares.ResolveFile(ac.Target)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return sourcePath, linkPath, info, nil
+}
+
+// doSymlink creates a symbolic link at the target pointing to the source.
+// The link is relative, so it keeps working when the share root moves, and
+// both ends must be in the same share, as the link would otherwise escape it.
+func doSymlink(ac *files.Action, rep *report) *files.ActionResponse {
+	sourcePath, linkPath, info, err := linkPaths(ac)
+	if err != nil {
+		return failure(err.Error())
+	}
+	sourceShare, _, _ := shares.Split(shares.VirtualPath(ac.Source))
+	linkShare, _, _ := shares.Split(shares.VirtualPath(ac.Target))
+	if sourceShare != linkShare {
+		return failure("Link '" + shares.VirtualPath(ac.Target) + "' and its target must be in the same share")
+	}
+	target, err := filepath.Rel(filepath.Dir(linkPath), sourcePath)
+	if err != nil {
+		return failure(err.Error())
+	}
+	linkPath, ok := linkConflict(sourcePath, linkPath, info, rep)
+	if ok {
+		rep.add(linkPath, os.Symlink(target, linkPath))
+	}
+	return rep.response("Linked " + shares.VirtualPath(ac.Target) + " to " + shares.VirtualPath(ac.Source))
+}
+
+// doHardlink creates a hard link to the source file at the target. Hard
+// links can only be created to regular files on the same file system.
+func doHardlink(ac *files.Action, rep *report) *files.ActionResponse {
+	sourcePath, linkPath, info, err := linkPaths(ac)
+	if err != nil {
+		return failure(err.Error())
+	}
+	if !info.Mode().IsRegular() {
+		return failure("Source '" + shares.VirtualPath(ac.Source) + "' is not a regular file")
+	}
+	linkPath, ok := linkConflict(sourcePath, linkPath, info, rep)
+	if ok {
+		rep.add(linkPath, os.Link(sourcePath, linkPath))
+	}
+	return rep.response("Linked " + shares.VirtualPath(ac.Target) + " to " + shares.VirtualPath(ac.Source))
+}
+
+// linkConflict resolves the conflict of the new link with an existing entry
+// by the policy of the action. An existing directory is never merged into
+// nor replaced by a link, as overwriting it would remove the whole tree.
+func linkConflict(sourcePath, linkPath string, info os.FileInfo, rep *report) (string, bool) {
+	dstInfo, err := os.Lstat(linkPath)
+	overwrite := rep.policy == files.ConflictPolicy_overwrite || rep.policy == files.ConflictPolicy_overwriteIfNewer
+	if err == nil && dstInfo.IsDir() && overwrite {
+		rep.conflict(sourcePath, linkPath, "failed")
+		rep.add(linkPath, errors.New("Cannot replace directory '"+virtualOf(linkPath)+"' with a link"))
+		return "", false
+	}
+	return resolveConflict(sourcePath, linkPath, info, rep)
+}
@@ -0,0 +1,332 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package actions
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+// modeBits are the bits chmod may change.
+const modeBits = os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky
+
+var authMtx = &sync.Mutex{}
+var authorization = &config.AuthorizationConfig{}
+
+// ConfigureAuthorization sets the users allowed to run the privileged actions.
+func ConfigureAuthorization(cfg *config.AuthorizationConfig) {
+	authMtx.Lock()
+	defer authMtx.Unlock()
+	authorization = cfg
+}
+
+// authorized returns an error unless the authenticated user is allowed to
+// run the action. Actions that are not privileged are allowed to everyone.
+func authorized(ac *files.Action, user string) error {
+	authMtx.Lock()
+	defer authMtx.Unlock()
+	var users []string
+	switch ac.Action {
+	case files.ActionType_chmod:
+		users = authorization.Chmod
+	case files.ActionType_chown:
+		users = authorization.Chown
+	default:
+		return nil
+	}
+	for _, u := range users {
+		if u == "*" || (u == user && user != "") {
+			return nil
+		}
+	}
+	return errors.New("User '" + user + "' is not allowed to " + ac.Action.String())
+}
+
+// changeMode computes the new mode of an entry from its current mode.
+type changeMode func(mode os.FileMode, isDir bool) os.FileMode
+
+// parseMode parses an octal mode, like "755" or "2775", or a comma separated
+// list of symbolic clauses, like "u+x,go-w" or "a=rX".
+func parseMode(spec string) (changeMode, error) {
+	if spec == "" {
+		return nil, errors.New("Missing mode")
+	}
+	if spec[0] >= '0' && spec[0] <= '7' {
+		value, err := strconv.ParseUint(spec, 8, 32)
+		if err != nil || value > 07777 {
+			return nil, errors.New("Invalid mode '" + spec + "'")
+		}
+		mode := fromUnix(uint32(value))
+		return func(os.FileMode, bool) os.FileMode {
+			return mode
+		}, nil
+	}
+	clauses := make([]func(os.FileMode, bool) os.FileMode, 0)
+	for _, clause := range strings.Split(spec, ",") {
+		apply, err := parseClause(clause)
+		if err != nil {
+			return nil, errors.New("Invalid mode '" + spec + "'")
+		}
+		clauses = append(clauses, apply)
+	}
+	return func(mode os.FileMode, isDir bool) os.FileMode {
+		for _, apply := range clauses {
+			mode = apply(mode, isDir)
+		}
+		return mode
+	}, nil
+}
+
+// parseClause parses a symbolic clause, the users (ugoa, all of them when
+// omitted) followed by one or more operations (+, - or =) and permissions
+// (rwxXst).
+func parseClause(clause string) (func(os.FileMode, bool) os.FileMode, error) {
+	i := 0
+	var who os.FileMode
+	for ; i < len(clause) && strings.IndexByte("ugoa", clause[i]) >= 0; i++ {
+		switch clause[i] {
+		case 'u':
+			who |= 04700
+		case 'g':
+			who |= 02070
+		case 'o':
+			who |= 01007
+		case 'a':
+			who |= 07777
+		}
+	}
+	if who == 0 {
+		who = 07777
+	}
+	type operation struct {
+		op    byte
+		perms string
+	}
+	ops := make([]operation, 0)
+	for i < len(clause) {
+		op := clause[i]
+		if op != '+' && op != '-' && op != '=' {
+			return nil, errors.New("invalid operation")
+		}
+		i++
+		start := i
+		for ; i < len(clause) && strings.IndexByte("rwxXst", clause[i]) >= 0; i++ {
+		}
+		ops = append(ops, operation{op: op, perms: clause[start:i]})
+	}
+	if len(ops) == 0 {
+		return nil, errors.New("missing operation")
+	}
+	return func(mode os.FileMode, isDir bool) os.FileMode {
+		bits := toUnix(mode)
+		for _, o := range ops {
+			var perms uint32
+			for _, p := range o.perms {
+				switch p {
+				case 'r':
+					perms |= 0444
+				case 'w':
+					perms |= 0222
+				case 'x':
+					perms |= 0111
+				case 'X':
+					if isDir || bits&0111 != 0 {
+						perms |= 0111
+					}
+				case 's':
+					perms |= 06000
+				case 't':
+					perms |= 01000
+				}
+			}
+			perms &= uint32(who)
+			switch o.op {
+			case '+':
+				bits |= perms
+			case '-':
+				bits &^= perms
+			case '=':
+				bits = bits&^uint32(who) | perms
+			}
+		}
+		return fromUnix(bits)
+	}, nil
+}
+
+// fromUnix converts the unix permission bits to a file mode.
+func fromUnix(bits uint32) os.FileMode {
+	mode := os.FileMode(bits & 0777)
+	if bits&04000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if bits&02000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if bits&01000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode
+}
+
+// toUnix converts the permissions of a file mode to the unix bits.
+func toUnix(mode os.FileMode) uint32 {
+	bits := uint32(mode & os.ModePerm)
+	if mode&os.ModeSetuid != 0 {
+		bits |= 04000
+	}
+	if mode&os.ModeSetgid != 0 {
+		bits |= 02000
+	}
+	if mode&os.ModeSticky != 0 {
+		bits |= 01000
+	}
+	return bits
+}
+
+// parseOwner parses "user", "user:group" or ":group", by name or by id,
+// returning -1 for the part that does not change.
+func parseOwner(spec string) (int, int, error) {
+	if spec == "" {
+		return -1, -1, errors.New("Missing owner")
+	}
+	userPart, groupPart, _ := strings.Cut(spec, ":")
+	uid, gid := -1, -1
+	if userPart != "" {
+		id, err := strconv.Atoi(userPart)
+		if err != nil {
+			u, lookupErr := user.Lookup(userPart)
+			if lookupErr != nil {
+				return -1, -1, errors.New("User '" + userPart + "' does not exist")
+			}
+			id, _ = strconv.Atoi(u.Uid)
+		}
+		uid = id
+	}
+	if groupPart != "" {
+		id, err := strconv.Atoi(groupPart)
+		if err != nil {
+			g, lookupErr := user.LookupGroup(groupPart)
+			if lookupErr != nil {
+				return -1, -1, errors.New("Group '" + groupPart + "' does not exist")
+			}
+			id, _ = strconv.Atoi(g.Gid)
+		}
+		gid = id
+	}
+	if uid < 0 && gid < 0 {
+		return -1, -1, errors.New("Invalid owner '" + spec + "'")
+	}
+	return uid, gid, nil
+}
+
+// doChmod changes the mode of the source, and of everything below it when
+// recursive, reporting the result of every path.
+func doChmod(ac *files.Action, rep *report) *files.ActionResponse {
+	change, err := parseMode(ac.Mode)
+	if err != nil {
+		return failure(err.Error())
+	}
+	return changeTree(ac, rep, func(path string, info os.FileInfo) (string, error) {
+		mode := change(info.Mode()&modeBits, info.IsDir())
+		err := os.Chmod(path, mode)
+		if err != nil {
+			return "", err
+		}
+		return "mode set to " + strconv.FormatUint(uint64(toUnix(mode)), 8), nil
+	})
+}
+
+// doChown changes the owner and group of the source, and of everything below
+// it when recursive, reporting the result of every path.
+func doChown(ac *files.Action, rep *report) *files.ActionResponse {
+	uid, gid, err := parseOwner(ac.Owner)
+	if err != nil {
+		return failure(err.Error())
+	}
+	return changeTree(ac, rep, func(path string, info os.FileInfo) (string, error) {
+		err := os.Lchown(path, uid, gid)
+		if err != nil {
+			return "", err
+		}
+		return "owner set to " + ac.Owner, nil
+	})
+}
+
+// changeTree applies change to the source and, when recursive, to the tree
+// below it. Symbolic links are not followed, as they may point outside of
+// the share, and their permissions are meaningless, so chmod skips them.
+func changeTree(ac *files.Action, rep *report, change func(string, os.FileInfo) (string, error)) *files.ActionResponse {
+	if ac.Source == nil {
+		return failure("source is nil")
+	}
+	if shares.IsRoot(shares.VirtualPath(ac.Source)) {
+		return failure("Path '/' is not inside a share")
+	}
+	sourcePath, err := shares.ResolveFile(ac.Source)
+	if err != nil {
+		return failure(err.Error())
+	}
+	if _, err = os.Lstat(sourcePath); err != nil {
+		return failure("Source '" + shares.VirtualPath(ac.Source) + "' does not exist")
+	}
+	if ac.Recursive {
+		rep.measure(sourcePath)
+	}
+	var walk func(path string)
+	walk = func(path string) {
+		if rep.stopped() {
+			return
+		}
+		info, err := os.Lstat(path)
+		if err != nil {
+			rep.add(path, err)
+			return
+		}
+		defer rep.progress(0, 1)
+		if info.Mode()&os.ModeSymlink != 0 && ac.Action == files.ActionType_chmod {
+			rep.done(path, "skipped symbolic link")
+			return
+		}
+		msg, err := change(path, info)
+		if err != nil {
+			rep.add(path, err)
+		} else {
+			rep.done(path, msg)
+		}
+		if !ac.Recursive || !info.IsDir() {
+			return
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			rep.add(path, err)
+			return
+		}
+		for _, entry := range entries {
+			walk(filepath.Join(path, entry.Name()))
+		}
+	}
+	walk(sourcePath)
+	return rep.response("Changed " + ac.Action.String() + " of " + shares.VirtualPath(ac.Source))
+}
@@ -0,0 +1,406 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package actions
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8types/go/ifs"
+)
+
+const (
+	// DefaultTextWindow is the number of bytes read without a length
+	DefaultTextWindow = 1 << 20
+	// MaxTextWindow caps the number of bytes of a single read
+	MaxTextWindow = 16 << 20
+	// MaxTextSize caps the size of a saved text file
+	MaxTextSize = 16 << 20
+
+	EncodingUtf8    = "utf-8"
+	EncodingUtf8Bom = "utf-8-bom"
+	EncodingUtf16LE = "utf-16le"
+	EncodingUtf16BE = "utf-16be"
+	EncodingLatin1  = "iso-8859-1"
+)
+
+// saveMtx serializes the saves, so the precondition of a save is checked
+// against the file as it is right before it is replaced.
+var saveMtx = &sync.Mutex{}
+
+// TextHandler reads a window of a text file with GET and saves a text file
+// with PUT, the file being in the "path" query parameter.
+func TextHandler(w http.ResponseWriter, r *http.Request, resources ifs.IResources) {
+	switch r.Method {
+	case http.MethodGet:
+		readText(w, r, resources)
+	case http.MethodPut:
+		saveText(w, r, resources)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// readText replies with the content of the file from the "offset" byte on,
+// "length" bytes at most, decoded to UTF-8. The window is moved to the
+// character boundaries of the detected encoding, the reply carries the
+// actual offset and length and whether there is "more" after it.
+func readText(w http.ResponseWriter, r *http.Request, resources ifs.IResources) {
+	virtualPath := r.URL.Query().Get("path")
+	realPath, err := shares.Resolve(virtualPath)
+	if err != nil || shares.IsRoot(virtualPath) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	file, err := os.Open(realPath)
+	if err != nil {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+	offset, _ := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	length, _ := strconv.ParseInt(r.URL.Query().Get("length"), 10, 64)
+	if length <= 0 {
+		length = DefaultTextWindow
+	}
+	if length > MaxTextWindow {
+		length = MaxTextWindow
+	}
+
+	head := make([]byte, 3)
+	n, _ := file.ReadAt(head, 0)
+	encoding, bom := bomEncoding(head[:n])
+	if offset < int64(bom) {
+		offset = int64(bom)
+	}
+	if offset > info.Size() {
+		offset = info.Size()
+	}
+	// Read a few more bytes to complete the last character
+	window := make([]byte, length+4)
+	n, err = file.ReadAt(window, offset)
+	if err != nil && err != io.EOF {
+		http.Error(w, "Error reading file", http.StatusInternalServerError)
+		return
+	}
+	window = window[:n]
+
+	var content string
+	var start, end int
+	switch encoding {
+	case EncodingUtf16LE, EncodingUtf16BE:
+		start, end = utf16Window(window, int((offset-int64(bom))%2), int(length), encoding == EncodingUtf16LE)
+		content = decodeUtf16(window[start:end], encoding == EncodingUtf16LE)
+	default:
+		start, end = utf8Window(window, int(length))
+		if encoding == "" {
+			encoding = EncodingUtf8
+			if !utf8.Valid(window[start:end]) {
+				encoding = EncodingLatin1
+			}
+		}
+		if bytes.IndexByte(window[start:end], 0) >= 0 {
+			http.Error(w, "File is not a text file", http.StatusUnsupportedMediaType)
+			return
+		}
+		if encoding == EncodingLatin1 {
+			content = decodeLatin1(window[start:end])
+		} else {
+			content = string(window[start:end])
+		}
+	}
+
+	text := &files.TextContent{Path: shares.Clean(virtualPath), Content: content, Encoding: encoding,
+		Offset: offset + int64(start), Length: int64(end - start), Size: info.Size(), Etag: etag(info),
+		Modified: info.ModTime().Unix()}
+	text.More = text.Offset+text.Length < info.Size()
+	w.Header().Set("ETag", text.Etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	err = writeJson(w, http.StatusOK, text)
+	if err != nil {
+		resources.Logger().Error("Error writing text response: ", err)
+	}
+}
+
+// bomEncoding returns the encoding given by the byte order mark, if any, and
+// the length of the mark.
+func bomEncoding(head []byte) (string, int) {
+	switch {
+	case bytes.HasPrefix(head, []byte{0xef, 0xbb, 0xbf}):
+		return EncodingUtf8Bom, 3
+	case bytes.HasPrefix(head, []byte{0xff, 0xfe}):
+		return EncodingUtf16LE, 2
+	case bytes.HasPrefix(head, []byte{0xfe, 0xff}):
+		return EncodingUtf16BE, 2
+	}
+	return "", 0
+}
+
+// utf8Window returns the part of the window of length bytes that starts and
+// ends on UTF-8 character boundaries. The window holds a few bytes past the
+// length, to complete a character cut by the length.
+func utf8Window(window []byte, length int) (int, int) {
+	start := 0
+	for start < 3 && start < len(window) && !utf8.RuneStart(window[start]) {
+		start++
+	}
+	end := length
+	if end > len(window) {
+		end = len(window)
+	}
+	if end < start {
+		end = start
+	}
+	for end < len(window) && end < length+3 && !utf8.RuneStart(window[end]) {
+		end++
+	}
+	return start, end
+}
+
+// utf16Window returns the part of the window of length bytes made of whole
+// UTF-16 code units, start skipping a byte when the offset was odd, and that
+// does not end in the middle of a surrogate pair.
+func utf16Window(window []byte, start, length int, littleEndian bool) (int, int) {
+	if start > len(window) {
+		start = len(window)
+	}
+	end := start + length - length%2
+	if end > len(window) {
+		end = len(window) - (len(window)-start)%2
+	}
+	if end-2 >= start && end+2 <= len(window) {
+		high := uint16(window[end-2])<<8 | uint16(window[end-1])
+		if littleEndian {
+			high = uint16(window[end-1])<<8 | uint16(window[end-2])
+		}
+		if high >= 0xd800 && high < 0xdc00 {
+			end += 2
+		}
+	}
+	return start, end
+}
+
+func decodeUtf16(b []byte, littleEndian bool) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		if littleEndian {
+			units[i] = uint16(b[2*i]) | uint16(b[2*i+1])<<8
+		} else {
+			units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+func decodeLatin1(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
+
+// encodeText encodes the UTF-8 content to the encoding a file is saved in.
+func encodeText(content []byte, encoding string) ([]byte, error) {
+	if !utf8.Valid(content) {
+		return nil, errors.New("Content is not valid UTF-8")
+	}
+	switch encoding {
+	case "", EncodingUtf8:
+		return content, nil
+	case EncodingUtf8Bom:
+		return append([]byte{0xef, 0xbb, 0xbf}, content...), nil
+	case EncodingUtf16LE, EncodingUtf16BE:
+		units := utf16.Encode([]rune(string(content)))
+		result := make([]byte, 2, 2+2*len(units))
+		little := encoding == EncodingUtf16LE
+		if little {
+			result[0], result[1] = 0xff, 0xfe
+		} else {
+			result[0], result[1] = 0xfe, 0xff
+		}
+		for _, u := range units {
+			if little {
+				result = append(result, byte(u), byte(u>>8))
+			} else {
+				result = append(result, byte(u>>8), byte(u))
+			}
+		}
+		return result, nil
+	case EncodingLatin1:
+		result := make([]byte, 0, len(content))
+		for _, c := range string(content) {
+			if c > 0xff {
+				return nil, errors.New("Content cannot be encoded in " + EncodingLatin1)
+			}
+			result = append(result, byte(c))
+		}
+		return result, nil
+	}
+	return nil, errors.New("Unsupported encoding '" + encoding + "'")
+}
+
+// saveText replaces the file with the UTF-8 body, encoded in the "encoding"
+// query parameter. A precondition is required, so a file changed by someone
+// else since it was read is not overwritten: If-Match with the ETag of the
+// read, or If-Unmodified-Since with its modification time, or
+// "If-None-Match: *" to create a new file.
+func saveText(w http.ResponseWriter, r *http.Request, resources ifs.IResources) {
+	virtualPath := r.URL.Query().Get("path")
+	realPath, err := shares.Resolve(virtualPath)
+	if err != nil || shares.IsRoot(virtualPath) || shares.IsShareRoot(virtualPath) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	ifMatch := r.Header.Get("If-Match")
+	ifUnmodified := r.Header.Get("If-Unmodified-Since")
+	create := r.Header.Get("If-None-Match") == "*"
+	if ifMatch == "" && ifUnmodified == "" && !create {
+		http.Error(w, "Saving requires an If-Match, If-Unmodified-Since or If-None-Match precondition",
+			http.StatusPreconditionRequired)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxTextSize))
+	if err != nil {
+		http.Error(w, uploadError(err).Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	content, err := encodeText(body, r.URL.Query().Get("encoding"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	saveMtx.Lock()
+	defer saveMtx.Unlock()
+	info, statErr := os.Stat(realPath)
+	_, lstatErr := os.Lstat(realPath)
+	switch {
+	case statErr == nil && !info.Mode().IsRegular():
+		http.Error(w, "Path is not a file", http.StatusBadRequest)
+		return
+	case create && lstatErr == nil,
+		!create && statErr != nil,
+		statErr == nil && ifMatch != "" && ifMatch != "*" && ifMatch != etag(info),
+		statErr == nil && ifUnmodified != "" && !unmodifiedSince(info, ifUnmodified):
+		if statErr == nil {
+			w.Header().Set("ETag", etag(info))
+		}
+		http.Error(w, "File was changed since it was read", http.StatusPreconditionFailed)
+		return
+	}
+
+	// A link is saved through, the rename would replace it with a file
+	targetPath := realPath
+	if statErr == nil {
+		targetPath, err = filepath.EvalSymlinks(realPath)
+		if err != nil {
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+	} else {
+		info = nil
+	}
+	err = writeAtomic(targetPath, content, info)
+	if err != nil {
+		resources.Logger().Error("Error saving text file: ", err)
+		http.Error(w, "Error saving file", http.StatusInternalServerError)
+		return
+	}
+	info, err = os.Stat(realPath)
+	if err != nil {
+		http.Error(w, "Error saving file", http.StatusInternalServerError)
+		return
+	}
+	text := &files.TextContent{Path: shares.Clean(virtualPath), Encoding: r.URL.Query().Get("encoding"),
+		Size: info.Size(), Etag: etag(info), Modified: info.ModTime().Unix()}
+	if text.Encoding == "" {
+		text.Encoding = EncodingUtf8
+	}
+	w.Header().Set("ETag", text.Etag)
+	err = writeJson(w, http.StatusOK, text)
+	if err != nil {
+		resources.Logger().Error("Error writing text response: ", err)
+	}
+}
+
+// unmodifiedSince returns true if the file was not modified after the http date.
+func unmodifiedSince(info os.FileInfo, date string) bool {
+	since, err := http.ParseTime(date)
+	if err != nil {
+		return false
+	}
+	return !info.ModTime().Truncate(time.Second).After(since)
+}
+
+// writeAtomic writes the content to a temporary file in the same directory,
+// syncs it and renames it over path, so readers see either the old or the
+// new content. The new file keeps the mode and the owner of the replaced one,
+// described by info, or is created with mode 0644 when info is nil.
+func writeAtomic(path string, content []byte, info os.FileInfo) error {
+	mode := os.FileMode(0644)
+	if info != nil {
+		mode = info.Mode().Perm()
+	}
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".save-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpName, mode)
+	}
+	if info != nil && err == nil {
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+			err = os.Chown(tmpName, int(stat.Uid), int(stat.Gid))
+		}
+	}
+	if err == nil {
+		err = os.Rename(tmpName, path)
+	}
+	if err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	syncDir(dir)
+	return nil
+}
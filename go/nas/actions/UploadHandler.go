@@ -0,0 +1,229 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package actions
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8types/go/ifs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// UploadHandler handles file upload requests into the directory given by the
+// "path" query parameter. The body is either multipart/form-data with one or
+// more files, or the raw file content with its name in the "name" query parameter.
+// The "policy" query parameter overrides the configured policy for existing files.
+func UploadHandler(w http.ResponseWriter, r *http.Request, resources ifs.IResources, cfg *config.UploadConfig) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	dirPath := r.URL.Query().Get("path")
+	if dirPath == "" {
+		http.Error(w, "Missing path parameter", http.StatusBadRequest)
+		return
+	}
+
+	realDir, err := shares.Resolve(dirPath)
+	if err != nil {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	dirInfo, err := os.Stat(realDir)
+	if err != nil || !dirInfo.IsDir() {
+		http.Error(w, "Target directory not found", http.StatusNotFound)
+		return
+	}
+
+	policy := r.URL.Query().Get("policy")
+	if policy == "" {
+		policy = cfg.Policy
+	}
+	if policy != config.UploadOverwrite && policy != config.UploadSkip && policy != config.UploadRename {
+		http.Error(w, "Invalid policy '"+policy+"'", http.StatusBadRequest)
+		return
+	}
+
+	if cfg.MaxSize > 0 {
+		if r.ContentLength > cfg.MaxSize {
+			http.Error(w, "Upload exceeds the maximum size of "+strconv.FormatInt(cfg.MaxSize, 10)+" bytes", http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, cfg.MaxSize)
+	}
+
+	rep := &report{}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				rep.add(realDir, uploadError(err))
+				break
+			}
+			if part.FileName() != "" {
+				storeUpload(realDir, part.FileName(), part, policy, rep)
+			}
+			part.Close()
+		}
+	} else {
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			http.Error(w, "Missing name parameter", http.StatusBadRequest)
+			return
+		}
+		storeUpload(realDir, name, r.Body, policy, rep)
+	}
+
+	resp := rep.response("Uploaded to " + shares.Clean(dirPath))
+	status := http.StatusOK
+	if resp.IsError {
+		status = http.StatusBadRequest
+	}
+	err = writeJson(w, status, resp)
+	if err != nil {
+		resources.Logger().Error("Error writing upload response: ", err)
+	}
+}
+
+// writeJson replies with the json encoding of pb.
+func writeJson(w http.ResponseWriter, status int, pb proto.Message) error {
+	data, err := protojson.Marshal(pb)
+	if err != nil {
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(data)
+	return err
+}
+
+// storeUpload streams body into a temporary file in dir, syncs it and then
+// commits it under name according to the policy.
+func storeUpload(dir, name string, body io.Reader, policy string, rep *report) {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == ".." || name == "/" {
+		rep.add(dir, errors.New("Invalid file name '"+name+"'"))
+		return
+	}
+	target := filepath.Join(dir, name)
+
+	tmp, err := os.CreateTemp(dir, ".upload-*.tmp")
+	if err != nil {
+		rep.add(target, err)
+		return
+	}
+	tmpName := tmp.Name()
+	_, err = io.Copy(tmp, body)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpName, 0644)
+	}
+	if err != nil {
+		os.Remove(tmpName)
+		rep.add(target, uploadError(err))
+		return
+	}
+
+	committed, err := commitFile(tmpName, target, policy)
+	if err != nil {
+		os.Remove(tmpName)
+		rep.add(target, err)
+		return
+	}
+	syncDir(dir)
+
+	if committed == "" {
+		rep.done(target, "skipped, already exists")
+		return
+	}
+	rep.done(committed, "uploaded")
+}
+
+// commitFile moves the fully written tmp file to target according to the
+// policy and returns the final path, or an empty path if it was skipped.
+// Skip and rename never replace an existing file, as the hard link
+// fails atomically if the target already exists.
+func commitFile(tmp, target, policy string) (string, error) {
+	if policy == config.UploadOverwrite {
+		return target, os.Rename(tmp, target)
+	}
+	for {
+		err := os.Link(tmp, target)
+		if err == nil {
+			return target, os.Remove(tmp)
+		}
+		if !os.IsExist(err) {
+			// The file system does not support hard links
+			if _, statErr := os.Lstat(target); statErr == nil {
+				if policy == config.UploadSkip {
+					return "", os.Remove(tmp)
+				}
+				target = uniqueName(target)
+				continue
+			}
+			return target, os.Rename(tmp, target)
+		}
+		if policy == config.UploadSkip {
+			return "", os.Remove(tmp)
+		}
+		target = uniqueName(target)
+	}
+}
+
+// syncDir flushes the directory entry of a committed file to disk.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
+
+func uploadError(err error) error {
+	maxErr := &http.MaxBytesError{}
+	if errors.As(err, &maxErr) {
+		return errors.New("Upload exceeds the maximum size of " + strconv.FormatInt(maxErr.Limit, 10) + " bytes")
+	}
+	return err
+}
@@ -0,0 +1,370 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package actions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8types/go/ifs"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	TusVersion       = "1.0.0"
+	SessionsEndpoint = "/files/upload/sessions"
+)
+
+// UploadSessions implements resumable uploads in the spirit of the tus protocol.
+// A session is created with a POST, its offset is queried with a HEAD and
+// chunks are appended with a PATCH at the current offset. The session metadata
+// is persisted in the session directory and the data is written to a hidden
+// part file in the target directory, so it can be committed with a rename.
+type UploadSessions struct {
+	cfg      *config.UploadConfig
+	sessions map[string]*uploadSession
+	mtx      *sync.Mutex
+}
+
+type uploadSession struct {
+	session *files.UploadSession
+	mtx     *sync.Mutex
+}
+
+func NewUploadSessions(cfg *config.UploadConfig) (*UploadSessions, error) {
+	err := os.MkdirAll(cfg.SessionDir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	this := &UploadSessions{cfg: cfg, sessions: make(map[string]*uploadSession), mtx: &sync.Mutex{}}
+	entries, err := os.ReadDir(cfg.SessionDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cfg.SessionDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		session := &files.UploadSession{}
+		err = protojson.Unmarshal(data, session)
+		if err != nil || session.Id == "" {
+			continue
+		}
+		this.sessions[session.Id] = &uploadSession{session: session, mtx: &sync.Mutex{}}
+	}
+	go this.expire()
+	return this, nil
+}
+
+// Handler serves the session endpoints, the session id is the last element
+// of the url path.
+func (this *UploadSessions) Handler(w http.ResponseWriter, r *http.Request, resources ifs.IResources) {
+	w.Header().Set("Tus-Resumable", TusVersion)
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, SessionsEndpoint), "/")
+	if id == "" {
+		switch r.Method {
+		case http.MethodPost:
+			this.create(w, r)
+		case http.MethodOptions:
+			w.Header().Set("Tus-Version", TusVersion)
+			if this.maxSize() > 0 {
+				w.Header().Set("Tus-Max-Size", strconv.FormatInt(this.maxSize(), 10))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	this.mtx.Lock()
+	us, ok := this.sessions[id]
+	this.mtx.Unlock()
+	if !ok {
+		http.Error(w, "Upload session not found", http.StatusNotFound)
+		return
+	}
+
+	us.mtx.Lock()
+	defer us.mtx.Unlock()
+	switch r.Method {
+	case http.MethodHead:
+		offset, err := this.offset(us.session)
+		if err != nil {
+			http.Error(w, "Upload session not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Upload-Offset", strconv.FormatInt(offset, 10))
+		w.Header().Set("Upload-Length", strconv.FormatInt(us.session.Size, 10))
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPatch:
+		this.patch(w, r, us.session, resources)
+	case http.MethodDelete:
+		this.remove(us.session)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// create starts a new session for the file "name" in the directory "path",
+// the total size is the Upload-Length header or the "size" query parameter.
+func (this *UploadSessions) create(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	dirPath := query.Get("path")
+	name := filepath.Base(strings.ReplaceAll(query.Get("name"), "\\", "/"))
+	if dirPath == "" || name == "" || name == "." || name == ".." || name == "/" {
+		http.Error(w, "Missing path or name parameter", http.StatusBadRequest)
+		return
+	}
+
+	sizeStr := r.Header.Get("Upload-Length")
+	if sizeStr == "" {
+		sizeStr = query.Get("size")
+	}
+	size, err := strconv.ParseInt(sizeStr, 10, 64)
+	if err != nil || size < 0 {
+		http.Error(w, "Missing or invalid upload length", http.StatusBadRequest)
+		return
+	}
+	if this.maxSize() > 0 && size > this.maxSize() {
+		http.Error(w, "Upload exceeds the maximum size of "+strconv.FormatInt(this.maxSize(), 10)+" bytes", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	policy := query.Get("policy")
+	if policy == "" {
+		policy = this.cfg.Policy
+	}
+	if policy != config.UploadOverwrite && policy != config.UploadSkip && policy != config.UploadRename {
+		http.Error(w, "Invalid policy '"+policy+"'", http.StatusBadRequest)
+		return
+	}
+
+	realDir, err := shares.Resolve(dirPath)
+	if err != nil {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	dirInfo, err := os.Stat(realDir)
+	if err != nil || !dirInfo.IsDir() {
+		http.Error(w, "Target directory not found", http.StatusNotFound)
+		return
+	}
+
+	id, err := newSessionId()
+	if err != nil {
+		http.Error(w, "Error creating upload session", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now().Unix()
+	session := &files.UploadSession{Id: id, Path: shares.Clean(dirPath), Name: name, Size: size,
+		Policy: policy, Created: now, Updated: now}
+
+	part, err := os.OpenFile(partFile(realDir, id), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		http.Error(w, "Error creating upload session", http.StatusInternalServerError)
+		return
+	}
+	part.Close()
+	err = this.save(session)
+	if err != nil {
+		os.Remove(partFile(realDir, id))
+		http.Error(w, "Error creating upload session", http.StatusInternalServerError)
+		return
+	}
+
+	this.mtx.Lock()
+	this.sessions[id] = &uploadSession{session: session, mtx: &sync.Mutex{}}
+	this.mtx.Unlock()
+
+	w.Header().Set("Location", SessionsEndpoint+"/"+id)
+	w.Header().Set("Upload-Offset", "0")
+	writeJson(w, http.StatusCreated, session)
+}
+
+// patch appends the body at the Upload-Offset header, which must match the
+// current offset of the session, and commits the file once it is complete.
+func (this *UploadSessions) patch(w http.ResponseWriter, r *http.Request, session *files.UploadSession, resources ifs.IResources) {
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		http.Error(w, "Missing or invalid Upload-Offset", http.StatusBadRequest)
+		return
+	}
+	realDir, err := shares.Resolve(session.Path)
+	if err != nil {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	current, err := this.offset(session)
+	if err != nil {
+		http.Error(w, "Upload session not found", http.StatusNotFound)
+		return
+	}
+	if offset != current {
+		w.Header().Set("Upload-Offset", strconv.FormatInt(current, 10))
+		http.Error(w, "Upload-Offset mismatch, current offset is "+strconv.FormatInt(current, 10), http.StatusConflict)
+		return
+	}
+
+	part, err := os.OpenFile(partFile(realDir, session.Id), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		http.Error(w, "Error opening upload", http.StatusInternalServerError)
+		return
+	}
+	// Read one byte more than remaining to detect a body exceeding the size
+	written, err := io.Copy(part, io.LimitReader(r.Body, session.Size-current+1))
+	tooLong := written > session.Size-current
+	if tooLong {
+		part.Truncate(session.Size)
+	}
+	syncErr := part.Sync()
+	part.Close()
+	if err == nil {
+		err = syncErr
+	}
+
+	session.Offset, _ = this.offset(session)
+	session.Updated = time.Now().Unix()
+	this.save(session)
+	w.Header().Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	if tooLong {
+		http.Error(w, "Upload exceeds the declared length", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		resources.Logger().Error("Error writing upload chunk: ", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if session.Offset < session.Size {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	rep := &report{}
+	target := filepath.Join(realDir, session.Name)
+	committed, err := commitFile(partFile(realDir, session.Id), target, session.Policy)
+	if err != nil {
+		rep.add(target, err)
+	} else if committed == "" {
+		rep.done(target, "skipped, already exists")
+	} else {
+		os.Chmod(committed, 0644)
+		syncDir(realDir)
+		rep.done(committed, "uploaded")
+	}
+	this.remove(session)
+	writeJson(w, http.StatusOK, rep.response("Uploaded "+session.Name))
+}
+
+// maxSize is the maximum size of a resumable upload, the maximum size of an
+// upload request unless a session maximum is configured.
+func (this *UploadSessions) maxSize() int64 {
+	if this.cfg.SessionMaxSize > 0 {
+		return this.cfg.SessionMaxSize
+	}
+	return this.cfg.MaxSize
+}
+
+// offset is the size of the part file, which is always what was synced to disk.
+func (this *UploadSessions) offset(session *files.UploadSession) (int64, error) {
+	realDir, err := shares.Resolve(session.Path)
+	if err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(partFile(realDir, session.Id))
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (this *UploadSessions) save(session *files.UploadSession) error {
+	data, err := protojson.Marshal(session)
+	if err != nil {
+		return err
+	}
+	filename := filepath.Join(this.cfg.SessionDir, session.Id+".json")
+	err = os.WriteFile(filename+".tmp", data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(filename+".tmp", filename)
+}
+
+// remove deletes the session with its part file, if it was not committed.
+func (this *UploadSessions) remove(session *files.UploadSession) {
+	this.mtx.Lock()
+	delete(this.sessions, session.Id)
+	this.mtx.Unlock()
+	realDir, err := shares.Resolve(session.Path)
+	if err == nil {
+		os.Remove(partFile(realDir, session.Id))
+	}
+	os.Remove(filepath.Join(this.cfg.SessionDir, session.Id+".json"))
+}
+
+// expire removes the sessions that were idle longer than the session timeout.
+func (this *UploadSessions) expire() {
+	for {
+		time.Sleep(time.Minute)
+		deadline := time.Now().Unix() - this.cfg.SessionTimeout
+		list := make([]*uploadSession, 0)
+		this.mtx.Lock()
+		for _, us := range this.sessions {
+			list = append(list, us)
+		}
+		this.mtx.Unlock()
+		for _, us := range list {
+			us.mtx.Lock()
+			if us.session.Updated < deadline {
+				this.remove(us.session)
+			}
+			us.mtx.Unlock()
+		}
+	}
+}
+
+func partFile(dir, id string) string {
+	return filepath.Join(dir, ".upload-"+id+".part")
+}
+
+func newSessionId() (string, error) {
+	buff := make([]byte, 16)
+	_, err := rand.Read(buff)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buff), nil
+}
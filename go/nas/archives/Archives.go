@@ -0,0 +1,360 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package archives browses the content of zip, tar and tar.gz archives as
+// virtual directories, so a path like /data/bundle.zip/inner/dir can be
+// listed and its files streamed without extracting the archive. The entries
+// of every archive are indexed once and cached until the archive changes.
+// Archives with more entries than the configured maximum are not indexed.
+package archives
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+)
+
+const (
+	formatZip   = "zip"
+	formatTar   = "tar"
+	formatTarGz = "tar.gz"
+	// MaxCached is the number of archive indexes kept in memory
+	MaxCached = 32
+)
+
+// entryInfo describes an archive entry, implicit directories included.
+type entryInfo struct {
+	name     string
+	size     int64
+	mode     fs.FileMode
+	modified time.Time
+	// position is the index of the archive member of the entry, -1 for the
+	// directories the archive omits
+	position int
+}
+
+func (this *entryInfo) Name() string       { return this.name }
+func (this *entryInfo) Size() int64        { return this.size }
+func (this *entryInfo) Mode() fs.FileMode  { return this.mode }
+func (this *entryInfo) ModTime() time.Time { return this.modified }
+func (this *entryInfo) IsDir() bool        { return this.mode.IsDir() }
+func (this *entryInfo) Sys() interface{}   { return nil }
+
+// index holds the entries of an archive by their clean relative path, the
+// root being "", and the names of the children of every directory.
+type index struct {
+	size     int64
+	modified time.Time
+	entries  map[string]*entryInfo
+	children map[string][]string
+}
+
+var mtx = &sync.Mutex{}
+var cache = make(map[string]*index)
+var maxEntries = config.Default().Archive.MaxEntries
+
+// Configure sets the maximum number of entries of a browsed archive, 0 is
+// unlimited.
+func Configure(cfg *config.ArchiveConfig) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	maxEntries = cfg.MaxEntries
+	cache = make(map[string]*index)
+}
+
+// format returns the format of a browsable archive by its name, or an empty
+// string if the file is not one.
+func format(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return formatZip
+	case strings.HasSuffix(lower, ".tar"):
+		return formatTar
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return formatTarGz
+	}
+	return ""
+}
+
+// IsArchive returns true if the file name is of an archive that can be browsed.
+func IsArchive(name string) bool {
+	return format(name) != ""
+}
+
+// Split finds the archive in the virtual path. It returns the real path of
+// the archive and the slash separated path inside of it, which is empty for
+// the archive itself, or false if the path is not in an archive.
+func Split(virtualPath string) (string, string, bool) {
+	share, rel, err := shares.Split(virtualPath)
+	if err != nil || rel == "" {
+		return "", "", false
+	}
+	parts := strings.Split(strings.Trim(shares.Clean("/"+rel), "/"), "/")
+	for i := range parts {
+		prefix := "/" + share.Name + "/" + strings.Join(parts[:i+1], "/")
+		realPath, err := shares.Resolve(prefix)
+		if err != nil {
+			return "", "", false
+		}
+		info, err := os.Stat(realPath)
+		if err != nil {
+			return "", "", false
+		}
+		if info.IsDir() {
+			continue
+		}
+		if !info.Mode().IsRegular() || !IsArchive(info.Name()) {
+			return "", "", false
+		}
+		return realPath, strings.Join(parts[i+1:], "/"), true
+	}
+	return "", "", false
+}
+
+// List returns the entries of the directory inside the archive.
+func List(realPath, inner string) ([]fs.DirEntry, error) {
+	idx, err := load(realPath)
+	if err != nil {
+		return nil, err
+	}
+	dir := clean(inner)
+	entry, ok := idx.entries[dir]
+	if !ok {
+		return nil, errors.New("Path '" + inner + "' does not exist in the archive")
+	}
+	if !entry.IsDir() {
+		return nil, errors.New("Path '" + inner + "' is not a directory")
+	}
+	names := idx.children[dir]
+	result := make([]fs.DirEntry, len(names))
+	for i, name := range names {
+		result[i] = fs.FileInfoToDirEntry(idx.entries[path.Join(dir, name)])
+	}
+	return result, nil
+}
+
+// Open returns the content of the file inside the archive with its info. Of
+// several members with the same name the last one is opened, the one the
+// listing shows and an extraction keeps.
+func Open(realPath, inner string) (io.ReadCloser, fs.FileInfo, error) {
+	idx, err := load(realPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	name := clean(inner)
+	entry, ok := idx.entries[name]
+	if !ok {
+		return nil, nil, errors.New("Path '" + inner + "' does not exist in the archive")
+	}
+	if !entry.mode.IsRegular() {
+		return nil, nil, errors.New("Path '" + inner + "' is not a file")
+	}
+	if format(realPath) == formatZip {
+		zr, err := zip.OpenReader(realPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if entry.position >= len(zr.File) || clean(zr.File[entry.position].Name) != name {
+			zr.Close()
+			return nil, nil, errors.New("Path '" + inner + "' does not exist in the archive")
+		}
+		reader, err := zr.File[entry.position].Open()
+		if err != nil {
+			zr.Close()
+			return nil, nil, err
+		}
+		return &readCloser{Reader: reader, closers: []io.Closer{reader, zr}}, entry, nil
+	}
+
+	// Tar archives can only be read sequentially up to the member
+	tr, closers, err := openTar(realPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc := &readCloser{Reader: tr, closers: closers}
+	for i := 0; ; i++ {
+		header, err := tr.Next()
+		if err != nil {
+			rc.Close()
+			if err == io.EOF {
+				err = errors.New("Path '" + inner + "' does not exist in the archive")
+			}
+			return nil, nil, err
+		}
+		if i == entry.position && clean(header.Name) == name && header.Typeflag == tar.TypeReg {
+			return rc, entry, nil
+		}
+		if i >= entry.position {
+			rc.Close()
+			return nil, nil, errors.New("Path '" + inner + "' does not exist in the archive")
+		}
+	}
+}
+
+// readCloser closes the member reader and the archive it was read from.
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (this *readCloser) Close() error {
+	var err error
+	for _, closer := range this.closers {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func openTar(realPath string) (*tar.Reader, []io.Closer, error) {
+	file, err := os.Open(realPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if format(realPath) != formatTarGz {
+		return tar.NewReader(file), []io.Closer{file}, nil
+	}
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return tar.NewReader(gz), []io.Closer{gz, file}, nil
+}
+
+// clean returns the entry name as a clean relative path, "" for the root.
+// Names climbing out of the archive are kept under its root.
+func clean(name string) string {
+	name = path.Clean("/" + strings.ReplaceAll(name, "\\", "/"))
+	return strings.TrimPrefix(name, "/")
+}
+
+// load returns the index of the archive, from the cache if the archive did
+// not change since it was indexed.
+func load(realPath string) (*index, error) {
+	info, err := os.Stat(realPath)
+	if err != nil {
+		return nil, err
+	}
+	mtx.Lock()
+	idx, ok := cache[realPath]
+	limit := maxEntries
+	mtx.Unlock()
+	if ok && idx.size == info.Size() && idx.modified.Equal(info.ModTime()) {
+		return idx, nil
+	}
+
+	idx = &index{size: info.Size(), modified: info.ModTime(), entries: make(map[string]*entryInfo),
+		children: make(map[string][]string)}
+	idx.entries[""] = &entryInfo{name: path.Base(realPath), mode: fs.ModeDir | 0755, modified: info.ModTime(), position: -1}
+	if format(realPath) == formatZip {
+		zr, err := zip.OpenReader(realPath)
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 && int64(len(zr.File)) > limit {
+			zr.Close()
+			return nil, tooManyEntries(limit)
+		}
+		for i, f := range zr.File {
+			fi := f.FileInfo()
+			idx.add(f.Name, fi.Size(), fi.Mode(), fi.ModTime(), i)
+		}
+		zr.Close()
+	} else {
+		tr, closers, err := openTar(realPath)
+		if err != nil {
+			return nil, err
+		}
+		err = idx.readTar(tr, limit)
+		(&readCloser{closers: closers}).Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, names := range idx.children {
+		sort.Strings(names)
+	}
+
+	mtx.Lock()
+	if len(cache) >= MaxCached {
+		cache = make(map[string]*index)
+	}
+	cache[realPath] = idx
+	mtx.Unlock()
+	return idx, nil
+}
+
+func (this *index) readTar(tr *tar.Reader, limit int64) error {
+	for i := 0; ; i++ {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if limit > 0 && int64(i) >= limit {
+			return tooManyEntries(limit)
+		}
+		this.add(header.Name, header.Size, header.FileInfo().Mode(), header.ModTime, i)
+	}
+}
+
+func tooManyEntries(limit int64) error {
+	return errors.New("Archive has more than " + strconv.FormatInt(limit, 10) + " entries")
+}
+
+// add indexes the entry at the position and its parent directories, which
+// archives may omit.
+func (this *index) add(name string, size int64, mode fs.FileMode, modified time.Time, position int) {
+	name = clean(name)
+	if name == "" {
+		return
+	}
+	if existing, ok := this.entries[name]; ok {
+		// A later entry replaces an earlier one, as when extracting
+		if existing.IsDir() && mode.IsDir() {
+			existing.mode, existing.modified, existing.position = mode, modified, position
+			return
+		}
+		existing.size, existing.mode, existing.modified, existing.position = size, mode, modified, position
+		return
+	}
+	this.entries[name] = &entryInfo{name: path.Base(name), size: size, mode: mode, modified: modified, position: position}
+	parent := path.Dir(name)
+	if parent == "." {
+		parent = ""
+	}
+	if _, ok := this.entries[parent]; !ok {
+		this.add(parent, 0, fs.ModeDir|0755, modified, -1)
+	}
+	this.children[parent] = append(this.children[parent], path.Base(name))
+}
@@ -0,0 +1,173 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+)
+
+const (
+	FileName = "nas.json"
+)
+
+const (
+	UploadOverwrite = "overwrite"
+	UploadSkip      = "skip"
+	UploadRename    = "rename"
+)
+
+// Config is the NAS server configuration, loaded from a json file in the
+// working directory of the server.
+type Config struct {
+	Shares []*shares.Share `json:"shares"`
+	Upload *UploadConfig   `json:"upload"`
+	Jobs   *JobsConfig     `json:"jobs"`
+	Trash  *TrashConfig    `json:"trash"`
+	Index  *IndexConfig    `json:"index"`
+	// Authorization lists the users allowed to run the privileged actions
+	Authorization *AuthorizationConfig `json:"authorization"`
+	Archive       *ArchiveConfig       `json:"archive"`
+	Thumbnails    *ThumbnailConfig     `json:"thumbnails"`
+}
+
+type UploadConfig struct {
+	// MaxSize is the maximum size in bytes of a single upload request, 0 is unlimited
+	MaxSize int64 `json:"maxSize"`
+	// Policy is the default policy when the uploaded file already exists,
+	// one of overwrite, skip or rename
+	Policy string `json:"policy"`
+	// SessionDir is where the resumable upload sessions are persisted
+	SessionDir string `json:"sessionDir"`
+	// SessionMaxSize is the maximum size in bytes of a resumable upload, 0 falls back to MaxSize
+	SessionMaxSize int64 `json:"sessionMaxSize"`
+	// SessionTimeout is the number of seconds an idle resumable upload is kept
+	SessionTimeout int64 `json:"sessionTimeout"`
+}
+
+type JobsConfig struct {
+	// History is the number of seconds a finished job is kept in the history
+	History int64 `json:"history"`
+}
+
+type TrashConfig struct {
+	// Enabled moves deleted files to the trash of their share instead of removing them
+	Enabled bool `json:"enabled"`
+	// MaxAge is the number of seconds a deleted file is kept in the trash, 0 is unlimited
+	MaxAge int64 `json:"maxAge"`
+	// MaxSize is the maximum size in bytes of the trash of a share, the oldest
+	// files are purged first when it is exceeded, 0 is unlimited
+	MaxSize int64 `json:"maxSize"`
+}
+
+type IndexConfig struct {
+	// Enabled builds the full text index of the text files in the shares
+	Enabled bool `json:"enabled"`
+	// Dir is where the index is persisted
+	Dir string `json:"dir"`
+	// Interval is the number of seconds between the scans for changed files
+	Interval int64 `json:"interval"`
+	// MaxFileSize is the size in bytes above which files are not indexed
+	MaxFileSize int64 `json:"maxFileSize"`
+}
+
+type AuthorizationConfig struct {
+	// Chmod are the users allowed to change permissions, "*" allows everyone
+	Chmod []string `json:"chmod"`
+	// Chown are the users allowed to change ownership, "*" allows everyone
+	Chown []string `json:"chown"`
+}
+
+type ArchiveConfig struct {
+	// MaxSize is the maximum size in bytes an extracted archive expands to, 0 is unlimited
+	MaxSize int64 `json:"maxSize"`
+	// MaxEntries is the maximum number of entries of an extracted or browsed archive, 0 is unlimited
+	MaxEntries int64 `json:"maxEntries"`
+}
+
+type ThumbnailConfig struct {
+	// Enabled pre-generates the thumbnails of the listed images in the background
+	Enabled bool `json:"enabled"`
+	// Dir is where the thumbnails are cached
+	Dir string `json:"dir"`
+	// Workers is the number of thumbnails generated concurrently, in the background and on demand
+	Workers int `json:"workers"`
+	// MaxAge is the number of seconds an unused thumbnail is kept in the cache, 0 is unlimited
+	MaxAge int64 `json:"maxAge"`
+}
+
+// Default returns the configuration used when there is no configuration
+// file, a single "home" share of the user home directory.
+func Default() *Config {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "/"
+	}
+	return &Config{
+		Shares: []*shares.Share{{Name: "home", Root: home}},
+		Upload: &UploadConfig{MaxSize: 1 << 30, Policy: UploadRename,
+			SessionDir: ".uploads", SessionMaxSize: 10 << 30, SessionTimeout: 86400},
+		Jobs:  &JobsConfig{History: 3600},
+		Trash: &TrashConfig{Enabled: true, MaxAge: 30 * 86400},
+		Index: &IndexConfig{Enabled: true, Dir: ".index", Interval: 300, MaxFileSize: 1 << 20},
+		// Nobody may change permissions or ownership unless configured
+		Authorization: &AuthorizationConfig{Chmod: []string{}, Chown: []string{}},
+		Archive:       &ArchiveConfig{MaxSize: 10 << 30, MaxEntries: 100000},
+		Thumbnails:    &ThumbnailConfig{Enabled: true, Dir: ".thumbnails", Workers: 2, MaxAge: 30 * 86400},
+	}
+}
+
+// Load reads the configuration from filename, falling back to the default
+// configuration if the file does not exist.
+func Load(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg := Default()
+	cfg.Shares = nil
+	err = json.Unmarshal(data, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Upload == nil {
+		cfg.Upload = Default().Upload
+	}
+	if cfg.Jobs == nil {
+		cfg.Jobs = Default().Jobs
+	}
+	if cfg.Trash == nil {
+		cfg.Trash = Default().Trash
+	}
+	if cfg.Index == nil {
+		cfg.Index = Default().Index
+	}
+	if cfg.Authorization == nil {
+		cfg.Authorization = Default().Authorization
+	}
+	if cfg.Archive == nil {
+		cfg.Archive = Default().Archive
+	}
+	if cfg.Thumbnails == nil {
+		cfg.Thumbnails = Default().Thumbnails
+	}
+	return cfg, nil
+}
@@ -17,9 +17,11 @@ package files
 
 import (
 	"os"
-	"strings"
+	"path/filepath"
 	"syscall"
 
+	"github.com/saichler/l8nasfile/go/nas/archives"
+	"github.com/saichler/l8nasfile/go/nas/shares"
 	"github.com/saichler/l8nasfile/go/types/files"
 	"github.com/saichler/l8srlz/go/serialize/object"
 	"github.com/saichler/l8types/go/ifs"
@@ -59,36 +61,53 @@ func (this *FileService) DeActivate() error {
 func (this *FileService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
 	f, ok := pb.Element().(*files.File)
 	if ok && f.IsDirectory {
-		subPath := f.Path + "/" + f.Name
-		if strings.HasPrefix(subPath, "//") {
-			subPath = subPath[1:]
+		subPath := shares.VirtualPath(f)
+		if shares.IsRoot(subPath) {
+			return object.New(nil, listShares())
 		}
-		fileList, err := os.ReadDir(subPath)
+		if archivePath, inner, ok := archives.Split(subPath); ok {
+			list, err := listArchive(archivePath, inner, shares.Clean(subPath), f.Options)
+			if err != nil {
+				return object.NewError(err.Error())
+			}
+			list.TotalSpace, list.FreeSpace, _ = Space(filepath.Dir(archivePath))
+			return object.New(nil, list)
+		}
+		realPath, err := shares.Resolve(subPath)
 		if err != nil {
 			return object.NewError(err.Error())
 		}
-		list := &files.FileList{}
-		list.TotalSpace, list.FreeSpace, err = Space(subPath)
-		list.Fiels = make([]*files.File, 0)
-		for _, file := range fileList {
-			ff := &files.File{}
-			ff.Name = file.Name()
-			ff.Path = subPath
-			ff.IsDirectory = file.IsDir()
-			list.Fiels = append(list.Fiels, ff)
-
-			info, err := file.Info()
-			if err != nil {
-				continue
-			}
-			ff.Size = info.Size()
-			ff.Modified = info.ModTime().Unix()
+		list, err := listDirectory(realPath, subPath, f.Options)
+		if err != nil {
+			return object.NewError(err.Error())
 		}
+		prefetchThumbnails(realPath, list.Fiels)
+		list.TotalSpace, list.FreeSpace, err = Space(realPath)
 		return object.New(nil, list)
 	}
 	return object.New(nil, &l8web.L8Empty{})
 }
 
+// listShares lists the configured shares as the directories of the root path.
+func listShares() *files.FileList {
+	list := &files.FileList{}
+	list.Fiels = make([]*files.File, 0)
+	for _, share := range shares.List() {
+		ff := &files.File{}
+		ff.Name = share.Name
+		ff.Path = "/"
+		ff.IsDirectory = true
+		list.Fiels = append(list.Fiels, ff)
+
+		info, err := os.Stat(share.RealRoot())
+		if err != nil {
+			continue
+		}
+		fillMetadata(ff, share.RealRoot(), info)
+	}
+	return list
+}
+
 func (this *FileService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
 	return nil
 }
@@ -0,0 +1,265 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package files
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/saichler/l8nasfile/go/nas/archives"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/thumbnails"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/proto"
+)
+
+// listEntry is a directory entry, its info is only read when it is needed
+// for sorting or when the entry is in the returned page. With sniff the
+// content of the files with an unknown extension is sniffed for their type.
+type listEntry struct {
+	dir   string
+	entry os.DirEntry
+	file  *files.File
+	info  bool
+	sniff bool
+}
+
+func (this *listEntry) load() {
+	if this.info {
+		return
+	}
+	this.info = true
+	info, err := this.entry.Info()
+	if err != nil {
+		return
+	}
+	realPath := filepath.Join(this.dir, this.entry.Name())
+	fillMetadata(this.file, realPath, info)
+	// Links are not followed, so their content is not sniffed either
+	if info.Mode().IsRegular() {
+		this.file.Type = DetectType(realPath, this.sniff)
+		// Entries inside an archive have no system info, nested archives cannot be browsed
+		this.file.IsArchive = info.Sys() != nil && archives.IsArchive(this.file.Name)
+	}
+}
+
+// listDirectory returns the page of the directory entries selected by the
+// options, directories first and then by the sort key. Without options, or
+// without a limit, all the entries are returned.
+func listDirectory(realPath, virtualPath string, options *files.ListOptions) (*files.FileList, error) {
+	dirEntries, err := os.ReadDir(realPath)
+	if err != nil {
+		return nil, err
+	}
+	return listEntries(dirEntries, realPath, virtualPath, options)
+}
+
+// listArchive returns the page of the entries of the directory inside the
+// archive, selected by the options as for a real directory.
+func listArchive(archivePath, inner, virtualPath string, options *files.ListOptions) (*files.FileList, error) {
+	dirEntries, err := archives.List(archivePath, inner)
+	if err != nil {
+		return nil, err
+	}
+	// The entries have no real path, the archive is not a directory
+	return listEntries(dirEntries, filepath.Join(archivePath, filepath.FromSlash(inner)), virtualPath, options)
+}
+
+// entryFilter selects the entries of a directory listing by the options.
+type entryFilter struct {
+	shareRoot  bool
+	hideHidden bool
+	filter     string
+	glob       bool
+}
+
+// newEntryFilter returns the filter of the options, the filter is a case
+// insensitive glob if it has any of "*?[" and a substring otherwise.
+func newEntryFilter(virtualPath string, options *files.ListOptions) (*entryFilter, error) {
+	filter := &entryFilter{shareRoot: shares.IsShareRoot(virtualPath), hideHidden: options.HideHidden,
+		filter: strings.ToLower(options.Filter)}
+	filter.glob = strings.ContainsAny(filter.filter, "*?[")
+	if filter.glob {
+		if _, err := filepath.Match(filter.filter, ""); err != nil {
+			return nil, errors.New("Invalid filter '" + options.Filter + "'")
+		}
+	}
+	return filter, nil
+}
+
+// keep returns true if the entry with the name is listed.
+func (this *entryFilter) keep(name string) bool {
+	// The trash is managed through the trash actions only
+	if this.shareRoot && name == trash.Dir {
+		return false
+	}
+	if this.hideHidden && strings.HasPrefix(name, ".") {
+		return false
+	}
+	if this.filter == "" {
+		return true
+	}
+	lower := strings.ToLower(name)
+	if this.glob {
+		ok, _ := filepath.Match(this.filter, lower)
+		return ok
+	}
+	return strings.Contains(lower, this.filter)
+}
+
+func listEntries(dirEntries []os.DirEntry, realPath, virtualPath string, options *files.ListOptions) (*files.FileList, error) {
+	if options == nil {
+		options = &files.ListOptions{}
+	}
+	filter, err := newEntryFilter(virtualPath, options)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*listEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if !filter.keep(name) {
+			continue
+		}
+		entries = append(entries, &listEntry{dir: realPath, entry: dirEntry, sniff: options.Sniff,
+			file: &files.File{Name: name, Path: virtualPath, IsDirectory: dirEntry.IsDir()}})
+	}
+
+	// Sorting by name needs no info, so large directories only stat the page
+	if options.Sort != files.SortKey_byName {
+		for _, entry := range entries {
+			entry.load()
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return less(entries[i].file, entries[j].file, options)
+	})
+
+	start := int(options.Offset)
+	if options.Cursor != "" {
+		after, err := decodeCursor(options.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		start = sort.Search(len(entries), func(i int) bool {
+			return less(after, entries[i].file, options)
+		})
+	}
+	if start < 0 || start > len(entries) {
+		start = len(entries)
+	}
+	end := len(entries)
+	if options.Limit > 0 && start+int(options.Limit) < end {
+		end = start + int(options.Limit)
+	}
+
+	list := &files.FileList{Total: int32(len(entries)), Offset: int32(start),
+		Fiels: make([]*files.File, 0, end-start)}
+	for _, entry := range entries[start:end] {
+		entry.load()
+		list.Fiels = append(list.Fiels, entry.file)
+	}
+	if end < len(entries) {
+		list.NextCursor = encodeCursor(entries[end-1].file)
+	}
+	return list, nil
+}
+
+// prefetchThumbnails queues the listed images of the real directory for
+// their thumbnails to be ready by the time the client asks for them.
+func prefetchThumbnails(realPath string, list []*files.File) {
+	images := make([]string, 0)
+	for _, f := range list {
+		if !f.IsDirectory && !f.IsSymlink && thumbnails.IsImage(f.Name) {
+			images = append(images, filepath.Join(realPath, f.Name))
+		}
+	}
+	if len(images) > 0 {
+		thumbnails.Prefetch(images)
+	}
+}
+
+// less orders directories first and then by the sort key, falling back to
+// the name so the order is total, which keeps the cursors stable.
+func less(a, b *files.File, options *files.ListOptions) bool {
+	if a.IsDirectory != b.IsDirectory {
+		return a.IsDirectory
+	}
+	c := 0
+	switch options.Sort {
+	case files.SortKey_bySize:
+		c = compareInt(a.Size, b.Size)
+	case files.SortKey_byModified:
+		c = compareInt(a.Modified, b.Modified)
+	case files.SortKey_byType:
+		c = strings.Compare(typeKey(a), typeKey(b))
+	}
+	if c == 0 {
+		c = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+	if c == 0 {
+		c = strings.Compare(a.Name, b.Name)
+	}
+	if options.Descending {
+		c = -c
+	}
+	return c < 0
+}
+
+func compareInt(a, b int64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// typeKey is the type of the file, or its extension when the type is unknown.
+func typeKey(f *files.File) string {
+	if f.Type != "" {
+		return f.Type
+	}
+	return strings.ToLower(filepath.Ext(f.Name))
+}
+
+// encodeCursor returns an opaque cursor pointing after the file, holding
+// all the fields the order depends on.
+func encodeCursor(f *files.File) string {
+	data, _ := proto.Marshal(&files.File{Name: f.Name, Size: f.Size, Modified: f.Modified,
+		Type: f.Type, IsDirectory: f.IsDirectory})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string) (*files.File, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("Invalid cursor")
+	}
+	f := &files.File{}
+	err = proto.Unmarshal(data, f)
+	if err != nil {
+		return nil, errors.New("Invalid cursor")
+	}
+	return f, nil
+}
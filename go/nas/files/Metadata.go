@@ -0,0 +1,157 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package files
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+var namesMtx = &sync.Mutex{}
+var userNames = make(map[uint32]string)
+var groupNames = make(map[uint32]string)
+
+// fillMetadata sets the POSIX metadata of the file from its lstat info, a
+// symbolic link is reported as such with its target and is not followed.
+func fillMetadata(f *files.File, realPath string, info os.FileInfo) {
+	f.Size = info.Size()
+	f.Modified = info.ModTime().Unix()
+	f.Permissions = permissions(info.Mode())
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if ok {
+		f.Mode = uint32(stat.Mode)
+		f.Uid = stat.Uid
+		f.Gid = stat.Gid
+		f.Owner = userName(stat.Uid)
+		f.Group = groupName(stat.Gid)
+		f.Links = uint64(stat.Nlink)
+		f.Inode = uint64(stat.Ino)
+		f.Accessed = stat.Atim.Sec
+		f.Changed = stat.Ctim.Sec
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		f.IsSymlink = true
+		f.LinkTarget, f.LinkIsDirectory = linkTarget(realPath)
+	}
+}
+
+// linkTarget returns the target of the symbolic link as the clients see it,
+// a relative target as is and an absolute one as a virtual path, and whether
+// it points to a directory. Targets that resolve outside of the shares, or
+// do not resolve at all, are not disclosed, whether absolute or relative.
+func linkTarget(realPath string) (string, bool) {
+	target, err := os.Readlink(realPath)
+	if err != nil {
+		return "", false
+	}
+	resolved := target
+	if !filepath.IsAbs(target) {
+		resolved = filepath.Join(filepath.Dir(realPath), target)
+	}
+	resolved, err = filepath.EvalSymlinks(resolved)
+	if err != nil {
+		return "", false
+	}
+	if _, err = shares.Virtual(resolved); err != nil {
+		return "", false
+	}
+	info, err := os.Stat(resolved)
+	isDirectory := err == nil && info.IsDir()
+	if !filepath.IsAbs(target) {
+		return target, isDirectory
+	}
+	virtualPath, err := shares.Virtual(filepath.Clean(target))
+	if err != nil {
+		return "", false
+	}
+	return virtualPath, isDirectory
+}
+
+// permissions formats the mode like ls does, "drwxr-xr-x".
+func permissions(mode os.FileMode) string {
+	buff := []byte("----------")
+	switch {
+	case mode.IsDir():
+		buff[0] = 'd'
+	case mode&os.ModeSymlink != 0:
+		buff[0] = 'l'
+	case mode&os.ModeNamedPipe != 0:
+		buff[0] = 'p'
+	case mode&os.ModeSocket != 0:
+		buff[0] = 's'
+	case mode&os.ModeCharDevice != 0:
+		buff[0] = 'c'
+	case mode&os.ModeDevice != 0:
+		buff[0] = 'b'
+	}
+	const rwx = "rwxrwxrwx"
+	for i := 0; i < 9; i++ {
+		if mode&(1<<uint(8-i)) != 0 {
+			buff[i+1] = rwx[i]
+		}
+	}
+	special := func(index int, set bool, letter byte) {
+		if !set {
+			return
+		}
+		if buff[index] == 'x' {
+			buff[index] = letter
+		} else {
+			buff[index] = letter - 'a' + 'A'
+		}
+	}
+	special(3, mode&os.ModeSetuid != 0, 's')
+	special(6, mode&os.ModeSetgid != 0, 's')
+	special(9, mode&os.ModeSticky != 0, 't')
+	return string(buff)
+}
+
+// userName resolves the uid to the user name, or its number if unknown.
+func userName(uid uint32) string {
+	namesMtx.Lock()
+	defer namesMtx.Unlock()
+	name, ok := userNames[uid]
+	if !ok {
+		name = strconv.FormatUint(uint64(uid), 10)
+		if u, err := user.LookupId(name); err == nil {
+			name = u.Username
+		}
+		userNames[uid] = name
+	}
+	return name
+}
+
+// groupName resolves the gid to the group name, or its number if unknown.
+func groupName(gid uint32) string {
+	namesMtx.Lock()
+	defer namesMtx.Unlock()
+	name, ok := groupNames[gid]
+	if !ok {
+		name = strconv.FormatUint(uint64(gid), 10)
+		if g, err := user.LookupGroupId(name); err == nil {
+			name = g.Name
+		}
+		groupNames[gid] = name
+	}
+	return name
+}
@@ -0,0 +1,106 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package files
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SniffLength is the number of leading bytes read to sniff the content type.
+const SniffLength = 512
+
+// extensionTypes completes the built in table of the mime package, which
+// depends on the mime.types files installed on the host.
+var extensionTypes = map[string]string{
+	".txt":  "text/plain; charset=utf-8",
+	".md":   "text/markdown; charset=utf-8",
+	".csv":  "text/csv; charset=utf-8",
+	".log":  "text/plain; charset=utf-8",
+	".yaml": "application/yaml",
+	".yml":  "application/yaml",
+	".go":   "text/x-go; charset=utf-8",
+	".py":   "text/x-python; charset=utf-8",
+	".sh":   "application/x-sh",
+	".mp3":  "audio/mpeg",
+	".flac": "audio/flac",
+	".ogg":  "audio/ogg",
+	".wav":  "audio/wav",
+	".mp4":  "video/mp4",
+	".mkv":  "video/x-matroska",
+	".webm": "video/webm",
+	".mov":  "video/quicktime",
+	".avi":  "video/x-msvideo",
+	".heic": "image/heic",
+	".bmp":  "image/bmp",
+	".tif":  "image/tiff",
+	".tiff": "image/tiff",
+	".ico":  "image/vnd.microsoft.icon",
+	".zip":  "application/zip",
+	".tar":  "application/x-tar",
+	".gz":   "application/gzip",
+	".tgz":  "application/gzip",
+	".zst":  "application/zstd",
+	".7z":   "application/x-7z-compressed",
+	".rar":  "application/vnd.rar",
+	".iso":  "application/x-iso9660-image",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xls":  "application/vnd.ms-excel",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".ppt":  "application/vnd.ms-powerpoint",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// DetectType returns the MIME type of the file from its extension. When the
+// extension is unknown and sniff is set, the type is sniffed from the first
+// bytes of the content. An empty string is returned when the type is unknown.
+func DetectType(realPath string, sniff bool) string {
+	ext := strings.ToLower(filepath.Ext(realPath))
+	if ext != "" {
+		if t, ok := extensionTypes[ext]; ok {
+			return t
+		}
+		if t := mime.TypeByExtension(ext); t != "" {
+			return t
+		}
+	}
+	if !sniff {
+		return ""
+	}
+	return sniffType(realPath)
+}
+
+func sniffType(realPath string) string {
+	file, err := os.Open(realPath)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	buff := make([]byte, SniffLength)
+	n, err := io.ReadFull(file, buff)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return ""
+	}
+	if n == 0 {
+		return ""
+	}
+	return http.DetectContentType(buff[:n])
+}
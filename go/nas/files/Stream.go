@@ -0,0 +1,180 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package files
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8types/go/ifs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultBatchSize = 256
+	MaxBatchSize     = 4096
+	// StatWorkers is the number of entries of a batch that are stat-ed concurrently
+	StatWorkers = 8
+)
+
+// StreamHandler lists the directory in the "path" query parameter as server
+// sent events. The directory is read in batches of "batch" entries, which are
+// stat-ed by a bounded pool of workers and sent as soon as they are ready, so
+// the first entries of a huge directory show up right away. The entries come
+// in directory order, "hideHidden=true", "filter" and "sniff=true" apply as in
+// the listing. A final "done" event carries the total and the disk space.
+func StreamHandler(w http.ResponseWriter, r *http.Request, resources ifs.IResources) {
+	virtualPath := shares.Clean(r.URL.Query().Get("path"))
+	if shares.IsRoot(virtualPath) {
+		http.Error(w, "Path '/' is not inside a share", http.StatusBadRequest)
+		return
+	}
+	realPath, err := shares.Resolve(virtualPath)
+	if err != nil {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	filter, err := newEntryFilter(virtualPath, &files.ListOptions{
+		HideHidden: r.URL.Query().Get("hideHidden") == "true", Filter: r.URL.Query().Get("filter")})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dir, err := os.Open(realPath)
+	if err != nil {
+		http.Error(w, "Directory not found", http.StatusNotFound)
+		return
+	}
+	defer dir.Close()
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	batchSize, err := strconv.Atoi(r.URL.Query().Get("batch"))
+	if err != nil || batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	if batchSize > MaxBatchSize {
+		batchSize = MaxBatchSize
+	}
+	sniff := r.URL.Query().Get("sniff") == "true"
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	pool := newStatPool(StatWorkers)
+	defer pool.close()
+	total := 0
+	for {
+		dirEntries, readErr := dir.ReadDir(batchSize)
+		entries := make([]*listEntry, 0, len(dirEntries))
+		for _, dirEntry := range dirEntries {
+			name := dirEntry.Name()
+			if !filter.keep(name) {
+				continue
+			}
+			entries = append(entries, &listEntry{dir: realPath, entry: dirEntry, sniff: sniff,
+				file: &files.File{Name: name, Path: virtualPath, IsDirectory: dirEntry.IsDir()}})
+		}
+		if len(entries) > 0 {
+			pool.load(entries)
+			batch := &files.FileList{Offset: int32(total), Fiels: make([]*files.File, len(entries))}
+			for i, entry := range entries {
+				batch.Fiels[i] = entry.file
+			}
+			total += len(entries)
+			prefetchThumbnails(realPath, batch.Fiels)
+			if !sendEvent(w, flusher, "files", batch, resources) {
+				return
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			resources.Logger().Error("Error reading directory: ", readErr)
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", strconv.Quote(readErr.Error()))
+			flusher.Flush()
+			return
+		}
+		if r.Context().Err() != nil {
+			return
+		}
+	}
+	done := &files.FileList{Total: int32(total)}
+	done.TotalSpace, done.FreeSpace, _ = Space(realPath)
+	sendEvent(w, flusher, "done", done, resources)
+}
+
+func sendEvent(w http.ResponseWriter, flusher http.Flusher, event string, pb proto.Message, resources ifs.IResources) bool {
+	data, err := protojson.Marshal(pb)
+	if err != nil {
+		resources.Logger().Error("Error encoding listing: ", err)
+		return false
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	if err != nil {
+		return false
+	}
+	flusher.Flush()
+	return true
+}
+
+// statPool loads the info of the entries with a fixed number of workers.
+type statPool struct {
+	work chan *statWork
+}
+
+type statWork struct {
+	entry *listEntry
+	wg    *sync.WaitGroup
+}
+
+func newStatPool(workers int) *statPool {
+	pool := &statPool{work: make(chan *statWork)}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for work := range pool.work {
+				work.entry.load()
+				work.wg.Done()
+			}
+		}()
+	}
+	return pool
+}
+
+// load returns once the info of all the entries was loaded.
+func (this *statPool) load(entries []*listEntry) {
+	wg := &sync.WaitGroup{}
+	wg.Add(len(entries))
+	for _, entry := range entries {
+		this.work <- &statWork{entry: entry, wg: wg}
+	}
+	wg.Wait()
+}
+
+func (this *statPool) close() {
+	close(this.work)
+}
@@ -0,0 +1,113 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hashes
+
+import (
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8web"
+	"github.com/saichler/l8utils/go/utils/web"
+)
+
+const (
+	ServiceName = "Hashes"
+	ServiceType = "HashService"
+	ServiceArea = byte(0)
+)
+
+type HashService struct {
+	sla *ifs.ServiceLevelAgreement
+}
+
+func Activate(vnic ifs.IVNic) {
+	sla := ifs.NewServiceLevelAgreement(&HashService{}, ServiceName, ServiceArea, false, nil)
+	ws := web.New(ServiceName, ServiceArea, 0)
+	ws.AddEndpoint(&files.HashRequest{}, ifs.POST, &files.HashResult{})
+	sla.SetWebService(ws)
+	vnic.Resources().Services().Activate(sla, vnic)
+}
+
+func (this *HashService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	vnic.Resources().Registry().Register(&files.HashRequest{})
+	vnic.Resources().Registry().Register(&files.HashResult{})
+	vnic.Resources().Registry().Register(&files.FileHash{})
+	vnic.Resources().Registry().Register(&files.Digest{})
+	vnic.Resources().Registry().Register(&l8web.L8Empty{})
+	this.sla = sla
+	return nil
+}
+
+func (this *HashService) DeActivate() error {
+	return nil
+}
+
+// Post starts hashing, or verifying, the paths when there is no id,
+// otherwise it returns the result of the calculation, cancelling it if
+// requested. Unless async, the result is returned once it is complete.
+func (this *HashService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	req, ok := pb.Element().(*files.HashRequest)
+	if !ok {
+		return object.New(nil, &l8web.L8Empty{})
+	}
+	var calc *Calculation
+	if req.Id == "" {
+		var err error
+		calc, err = Start(req)
+		if err != nil {
+			return object.NewError(err.Error())
+		}
+	} else {
+		calc = Get(req.Id)
+		if calc == nil {
+			return object.NewError("Hash calculation '" + req.Id + "' does not exist")
+		}
+		if req.Cancel {
+			Cancel(req.Id)
+		}
+	}
+	if !req.Async {
+		calc.Wait()
+	}
+	return object.New(nil, calc.Result())
+}
+
+func (this *HashService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *HashService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *HashService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+
+func (this *HashService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *HashService) GetCopy(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *HashService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+func (this *HashService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *HashService) WebService() ifs.IWebService {
+	return this.sla.WebService()
+}
@@ -0,0 +1,451 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package hashes calculates the digests of files, or a manifest of every
+// file under a directory, and verifies them against a SHA256SUMS style
+// manifest. Files are streamed through all the requested algorithms at
+// once and a bounded number of files are hashed concurrently, across all
+// the calculations. The progress is tracked per file, so the progress of
+// a large file shows while it is hashed.
+package hashes
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/registry"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"golang.org/x/crypto/blake2b"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Workers is the number of files hashed concurrently by all the calculations
+	Workers = 4
+	// MaxFiles bounds the number of files of a single calculation
+	MaxFiles = 100000
+	// chunk is the size of the reads, the progress is updated after each one
+	chunk = 1 << 20
+	// keep is how long a finished calculation is kept for its result to be fetched
+	keep = 10 * time.Minute
+)
+
+// Calculation is a running or finished hash calculation of one or more paths.
+type Calculation struct {
+	id         string
+	mtx        *sync.Mutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	done       chan struct{}
+	algorithms []files.HashAlgorithm
+	manifest   *manifest
+	result     *files.HashResult
+	ended      time.Time
+}
+
+// task is a file to hash, with the manifest entry it is verified against.
+type task struct {
+	file     *files.FileHash
+	realPath string
+	expected *entry
+}
+
+var calculations = registry.New(keep)
+
+// slots bounds the number of files hashed at the same time
+var slots = make(chan struct{}, Workers)
+
+// Start hashes the paths of the request in the background. Without
+// algorithms the files are hashed with SHA-256. With a manifest, or a
+// manifest path, the files are verified against it instead.
+func Start(req *files.HashRequest) (*Calculation, error) {
+	if len(req.Paths) == 0 {
+		return nil, errors.New("No paths to hash")
+	}
+	algorithms := make([]files.HashAlgorithm, 0, len(req.Algorithms))
+	for _, algorithm := range req.Algorithms {
+		if _, ok := files.HashAlgorithm_name[int32(algorithm)]; !ok {
+			return nil, errors.New("Unknown algorithm '" + strconv.Itoa(int(algorithm)) + "'")
+		}
+		algorithms = appendAlgorithm(algorithms, algorithm)
+	}
+	if len(algorithms) == 0 {
+		algorithms = append(algorithms, files.HashAlgorithm_sha256)
+	}
+
+	var m *manifest
+	text := req.Manifest
+	if text == "" && req.ManifestPath != "" {
+		data, err := readManifest(req.ManifestPath)
+		if err != nil {
+			return nil, err
+		}
+		text = data
+	}
+	if text != "" {
+		var err error
+		m, err = parseManifest(text, req.Algorithms)
+		if err != nil {
+			return nil, err
+		}
+		// Only the algorithms of the manifest are needed to verify it
+		algorithms = m.algorithms
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calc := &Calculation{id: registry.NewId(), mtx: &sync.Mutex{}, ctx: ctx, cancel: cancel,
+		done: make(chan struct{}), algorithms: algorithms, manifest: m,
+		result: &files.HashResult{Files: make([]*files.FileHash, 0)}}
+	calc.result.Id = calc.id
+	calculations.Add(calc.id, calc)
+
+	go func() {
+		calc.run(req.Paths)
+		calc.mtx.Lock()
+		calc.finish()
+		calc.ended = time.Now()
+		calc.mtx.Unlock()
+		calc.cancel()
+		close(calc.done)
+	}()
+	return calc, nil
+}
+
+// run plans the files of all the paths and then hashes them with the
+// shared workers.
+func (this *Calculation) run(paths []string) {
+	tasks := make([]*task, 0)
+	count := 0
+	for _, path := range paths {
+		planned, others := this.plan(shares.Clean(path), MaxFiles-count)
+		count += len(planned) + len(others)
+		if count > MaxFiles {
+			this.add(&files.FileHash{Path: shares.Clean(path),
+				Error: "Path '" + shares.Clean(path) + "' has more than " + strconv.Itoa(MaxFiles) + " files"})
+			break
+		}
+		for _, t := range planned {
+			this.mtx.Lock()
+			this.result.Files = append(this.result.Files, t.file)
+			this.result.TotalFiles++
+			this.result.TotalBytes += t.file.Size
+			this.mtx.Unlock()
+		}
+		for _, f := range others {
+			this.add(f)
+		}
+		tasks = append(tasks, planned...)
+	}
+
+	queue := make(chan *task)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range queue {
+				this.hash(t)
+			}
+		}()
+	}
+	for _, t := range tasks {
+		if this.ctx.Err() != nil {
+			break
+		}
+		queue <- t
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// plan returns the files of the path to hash and the ones that are only
+// reported, the errors and the unlisted and missing files of a verification.
+// A directory is walked without following links and without its trash, and
+// the walk stops once there are more than limit files.
+func (this *Calculation) plan(path string, limit int) ([]*task, []*files.FileHash) {
+	if shares.IsRoot(path) {
+		return nil, []*files.FileHash{{Path: path, Error: "Path '/' is not inside a share"}}
+	}
+	realPath, err := shares.Resolve(path)
+	if err != nil {
+		return nil, []*files.FileHash{{Path: path, Error: err.Error()}}
+	}
+	info, err := os.Lstat(realPath)
+	if err != nil {
+		return nil, []*files.FileHash{{Path: path, Error: "File not found"}}
+	}
+	if !info.IsDir() {
+		if !info.Mode().IsRegular() {
+			return nil, []*files.FileHash{{Path: path, Error: "Path is not a regular file"}}
+		}
+		name := filepath.Base(realPath)
+		f := &files.FileHash{Path: path, Name: name, Size: info.Size()}
+		if this.manifest == nil {
+			return []*task{{file: f, realPath: realPath}}, nil
+		}
+		expected := this.manifest.lookup(name)
+		if expected == nil {
+			f.Status = files.HashStatus_unlisted
+			return nil, []*files.FileHash{f}
+		}
+		return []*task{{file: f, realPath: realPath, expected: expected}}, nil
+	}
+
+	tasks := make([]*task, 0)
+	others := make([]*files.FileHash, 0)
+	found := make(map[string]bool)
+	filepath.WalkDir(realPath, func(sub string, d fs.DirEntry, err error) error {
+		if this.ctx.Err() != nil || len(tasks)+len(others) > limit {
+			return filepath.SkipAll
+		}
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if trash.IsTrash(sub) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(realPath, sub)
+		name := filepath.ToSlash(rel)
+		f := &files.FileHash{Path: shares.Clean(path + "/" + name), Name: name, Size: info.Size()}
+		var expected *entry
+		if this.manifest != nil {
+			expected = this.manifest.entries[name]
+			if expected == nil {
+				f.Status = files.HashStatus_unlisted
+				others = append(others, f)
+				return nil
+			}
+			found[name] = true
+		}
+		tasks = append(tasks, &task{file: f, realPath: sub, expected: expected})
+		return nil
+	})
+
+	if this.manifest != nil {
+		for _, e := range this.manifest.missing(found) {
+			others = append(others, &files.FileHash{Path: shares.Clean(path + "/" + e.name), Name: e.name,
+				Status: files.HashStatus_missing})
+		}
+	}
+	return tasks, others
+}
+
+func (this *Calculation) add(f *files.FileHash) {
+	this.mtx.Lock()
+	this.result.Files = append(this.result.Files, f)
+	this.mtx.Unlock()
+}
+
+// hash streams the file through the hashes of the calculation, waiting
+// for a free slot first.
+func (this *Calculation) hash(t *task) {
+	select {
+	case slots <- struct{}{}:
+	case <-this.ctx.Done():
+		return
+	}
+	defer func() { <-slots }()
+
+	digests, err := hashFile(this.ctx, t.realPath, this.algorithms, func(n int64) {
+		this.mtx.Lock()
+		t.file.Hashed += n
+		this.result.HashedBytes += n
+		this.mtx.Unlock()
+	})
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	if err != nil {
+		if this.ctx.Err() == nil {
+			t.file.Error = err.Error()
+		}
+		return
+	}
+	t.file.Digests = digests
+	this.result.HashedFiles++
+	if t.expected != nil {
+		t.file.Status = files.HashStatus_mismatched
+		if digestOf(digests, t.expected.algorithm) == t.expected.digest {
+			t.file.Status = files.HashStatus_matched
+		}
+	}
+}
+
+// finish sets the verification counts and the manifest of the hashed files.
+func (this *Calculation) finish() {
+	if this.ctx.Err() != nil {
+		this.result.Cancelled = true
+	}
+	for _, f := range this.result.Files {
+		switch f.Status {
+		case files.HashStatus_matched:
+			this.result.Matched++
+		case files.HashStatus_mismatched:
+			this.result.Mismatched++
+		case files.HashStatus_missing:
+			this.result.Missing++
+		case files.HashStatus_unlisted:
+			this.result.Unlisted++
+		}
+	}
+	sort.SliceStable(this.result.Files, func(i, j int) bool {
+		return this.result.Files[i].Path < this.result.Files[j].Path
+	})
+	if this.manifest == nil && !this.result.Cancelled {
+		this.result.Manifest = formatManifest(this.result.Files, this.algorithms[0])
+	}
+}
+
+// hashFile returns the digests of the file, calling progress after every
+// chunk read.
+func hashFile(ctx context.Context, realPath string, algorithms []files.HashAlgorithm, progress func(int64)) ([]*files.Digest, error) {
+	file, err := os.Open(realPath)
+	if err != nil {
+		return nil, errors.New("Error opening file")
+	}
+	defer file.Close()
+	hashes := make([]hash.Hash, len(algorithms))
+	writers := make([]io.Writer, len(algorithms))
+	for i, algorithm := range algorithms {
+		hashes[i] = newHash(algorithm)
+		writers[i] = hashes[i]
+	}
+	w := io.MultiWriter(writers...)
+	buff := make([]byte, chunk)
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		n, err := file.Read(buff)
+		if n > 0 {
+			w.Write(buff[:n])
+			progress(int64(n))
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("Error reading file")
+		}
+	}
+	digests := make([]*files.Digest, len(algorithms))
+	for i, algorithm := range algorithms {
+		digests[i] = &files.Digest{Algorithm: algorithm, Value: hex.EncodeToString(hashes[i].Sum(nil))}
+	}
+	return digests, nil
+}
+
+func newHash(algorithm files.HashAlgorithm) hash.Hash {
+	switch algorithm {
+	case files.HashAlgorithm_sha1:
+		return sha1.New()
+	case files.HashAlgorithm_md5:
+		return md5.New()
+	case files.HashAlgorithm_crc32:
+		return crc32.NewIEEE()
+	case files.HashAlgorithm_blake2b:
+		h, _ := blake2b.New512(nil)
+		return h
+	case files.HashAlgorithm_blake2b256:
+		h, _ := blake2b.New256(nil)
+		return h
+	}
+	return sha256.New()
+}
+
+func digestOf(digests []*files.Digest, algorithm files.HashAlgorithm) string {
+	for _, digest := range digests {
+		if digest.Algorithm == algorithm {
+			return digest.Value
+		}
+	}
+	return ""
+}
+
+func appendAlgorithm(algorithms []files.HashAlgorithm, algorithm files.HashAlgorithm) []files.HashAlgorithm {
+	for _, a := range algorithms {
+		if a == algorithm {
+			return algorithms
+		}
+	}
+	return append(algorithms, algorithm)
+}
+
+// Get returns the calculation by its id, or nil if there is no such calculation.
+func Get(id string) *Calculation {
+	calc, _ := calculations.Get(id).(*Calculation)
+	return calc
+}
+
+// Cancel stops the calculation, returns false if there is no such calculation.
+func Cancel(id string) bool {
+	return calculations.Cancel(id)
+}
+
+// Cancel stops the calculation.
+func (this *Calculation) Cancel() {
+	this.cancel()
+}
+
+// Ended returns when the calculation finished, the zero time while it runs.
+func (this *Calculation) Ended() time.Time {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	return this.ended
+}
+
+func (this *Calculation) Id() string {
+	return this.id
+}
+
+// Result returns the files hashed so far, with the progress of the ones
+// that are still being hashed.
+func (this *Calculation) Result() *files.HashResult {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	result := proto.Clone(this.result).(*files.HashResult)
+	result.Done = !this.ended.IsZero()
+	return result
+}
+
+// Wait blocks until the calculation is finished.
+func (this *Calculation) Wait() {
+	<-this.done
+}
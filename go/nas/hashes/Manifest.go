@@ -0,0 +1,241 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hashes
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+// MaxManifestSize bounds the size of a manifest file
+const MaxManifestSize = 16 << 20
+
+// entry is a file listed in a manifest with its expected digest.
+type entry struct {
+	name      string
+	algorithm files.HashAlgorithm
+	digest    string
+}
+
+// manifest is a parsed SHA256SUMS style manifest, the names are relative
+// to the verified directory.
+type manifest struct {
+	entries    map[string]*entry
+	order      []*entry
+	algorithms []files.HashAlgorithm
+}
+
+// tags are the algorithm names of the BSD style lines, in upper case.
+var tags = map[string]files.HashAlgorithm{
+	"SHA256":      files.HashAlgorithm_sha256,
+	"SHA1":        files.HashAlgorithm_sha1,
+	"MD5":         files.HashAlgorithm_md5,
+	"CRC32":       files.HashAlgorithm_crc32,
+	"BLAKE2B":     files.HashAlgorithm_blake2b,
+	"BLAKE2B-512": files.HashAlgorithm_blake2b,
+	"BLAKE2B-256": files.HashAlgorithm_blake2b256,
+}
+
+// digestLengths are the lengths of the hex digests of the algorithms.
+var digestLengths = map[files.HashAlgorithm]int{
+	files.HashAlgorithm_sha256:     64,
+	files.HashAlgorithm_sha1:       40,
+	files.HashAlgorithm_md5:        32,
+	files.HashAlgorithm_crc32:      8,
+	files.HashAlgorithm_blake2b:    128,
+	files.HashAlgorithm_blake2b256: 64,
+}
+
+// parseManifest parses the GNU coreutils lines, "<digest>  <name>" or
+// "<digest> *<name>", and the BSD style lines, "SHA256 (<name>) = <digest>".
+// The algorithm of the GNU lines is the first requested one, or else it is
+// guessed from the length of the digest, SHA-256 for 64 hex digits.
+func parseManifest(text string, requested []files.HashAlgorithm) (*manifest, error) {
+	m := &manifest{entries: make(map[string]*entry), order: make([]*entry, 0),
+		algorithms: make([]files.HashAlgorithm, 0)}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		e, err := parseLine(line, requested)
+		if err != nil {
+			return nil, errors.New("Invalid manifest line " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+		if _, ok := m.entries[e.name]; ok {
+			continue
+		}
+		m.entries[e.name] = e
+		m.order = append(m.order, e)
+		m.algorithms = appendAlgorithm(m.algorithms, e.algorithm)
+	}
+	if len(m.order) == 0 {
+		return nil, errors.New("Manifest has no entries")
+	}
+	return m, nil
+}
+
+func parseLine(line string, requested []files.HashAlgorithm) (*entry, error) {
+	// GNU tools escape the lines of names with a backslash or a new line
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+	var name, digest string
+	algorithm, tagged := files.HashAlgorithm(0), false
+	if open := strings.Index(line, " ("); open > 0 {
+		end := strings.LastIndex(line, ") = ")
+		if a, ok := tags[strings.ToUpper(line[:open])]; ok && end > open {
+			algorithm, tagged = a, true
+			name, digest = line[open+2:end], line[end+4:]
+		}
+	}
+	if !tagged {
+		space := strings.IndexByte(line, ' ')
+		if space < 0 {
+			return nil, errors.New("missing file name")
+		}
+		digest, name = line[:space], line[space+1:]
+		if strings.HasPrefix(name, " ") || strings.HasPrefix(name, "*") {
+			name = name[1:]
+		}
+		algorithm = guess(digest, requested)
+	}
+	digest = strings.ToLower(digest)
+	if _, err := hex.DecodeString(digest); err != nil || len(digest) != digestLengths[algorithm] {
+		return nil, errors.New("invalid " + algorithm.String() + " digest '" + digest + "'")
+	}
+	if escaped {
+		name = unescape(name)
+	}
+	clean := path.Clean(strings.TrimPrefix(name, "./"))
+	if name == "" || path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return nil, errors.New("invalid name '" + name + "'")
+	}
+	return &entry{name: clean, algorithm: algorithm, digest: digest}, nil
+}
+
+func guess(digest string, requested []files.HashAlgorithm) files.HashAlgorithm {
+	if len(requested) > 0 {
+		return requested[0]
+	}
+	switch len(digest) {
+	case 8:
+		return files.HashAlgorithm_crc32
+	case 32:
+		return files.HashAlgorithm_md5
+	case 40:
+		return files.HashAlgorithm_sha1
+	case 128:
+		return files.HashAlgorithm_blake2b
+	}
+	return files.HashAlgorithm_sha256
+}
+
+func unescape(name string) string {
+	buff := &strings.Builder{}
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+1 < len(name) {
+			i++
+			if name[i] == 'n' {
+				buff.WriteByte('\n')
+				continue
+			}
+		}
+		buff.WriteByte(name[i])
+	}
+	return buff.String()
+}
+
+// lookup returns the entry of a single verified file, by its name or else
+// by the last element of the listed names.
+func (this *manifest) lookup(name string) *entry {
+	if e, ok := this.entries[name]; ok {
+		return e
+	}
+	for _, e := range this.order {
+		if path.Base(e.name) == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// missing returns the entries that were not found, in the manifest order.
+func (this *manifest) missing(found map[string]bool) []*entry {
+	result := make([]*entry, 0)
+	for _, e := range this.order {
+		if !found[e.name] {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// readManifest returns the content of the manifest file in the virtual path.
+func readManifest(virtualPath string) (string, error) {
+	realPath, err := shares.Resolve(virtualPath)
+	if err != nil || shares.IsRoot(virtualPath) {
+		return "", errors.New("Access denied to manifest '" + shares.Clean(virtualPath) + "'")
+	}
+	file, err := os.Open(realPath)
+	if err != nil {
+		return "", errors.New("Manifest '" + shares.Clean(virtualPath) + "' does not exist")
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return "", errors.New("Manifest '" + shares.Clean(virtualPath) + "' is not a file")
+	}
+	if info.Size() > MaxManifestSize {
+		return "", errors.New("Manifest '" + shares.Clean(virtualPath) + "' is too large")
+	}
+	data, err := io.ReadAll(io.LimitReader(file, MaxManifestSize))
+	if err != nil {
+		return "", errors.New("Error reading manifest '" + shares.Clean(virtualPath) + "'")
+	}
+	return string(data), nil
+}
+
+// formatManifest returns the digests of the hashed files in the GNU
+// coreutils format, so "sha256sum -c" can check them.
+func formatManifest(list []*files.FileHash, algorithm files.HashAlgorithm) string {
+	buff := &strings.Builder{}
+	for _, f := range list {
+		digest := digestOf(f.Digests, algorithm)
+		if digest == "" {
+			continue
+		}
+		name := f.Name
+		if strings.ContainsAny(name, "\\\n") {
+			buff.WriteByte('\\')
+			name = strings.ReplaceAll(strings.ReplaceAll(name, "\\", "\\\\"), "\n", "\\n")
+		}
+		buff.WriteString(digest)
+		buff.WriteString("  ")
+		buff.WriteString(name)
+		buff.WriteByte('\n')
+	}
+	return buff.String()
+}
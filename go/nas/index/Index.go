@@ -0,0 +1,456 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package index maintains a persistent inverted index of the words in the
+// text files of the shares. The shares are scanned periodically and only
+// the files whose size or modification time changed are read again, files
+// that are gone are dropped from the index. Binary files are recognized by
+// sniffing their content and are not indexed.
+package index
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+const (
+	// FileName is the name of the persisted index in the index directory
+	FileName = "index.gob"
+	// DefaultLimit is the number of hits of a query without a limit
+	DefaultLimit = 100
+	// MaxLimit caps the number of hits of a single query
+	MaxLimit = 1000
+	// MaxSnippets is the number of matching lines returned per hit
+	MaxSnippets = 3
+	// snippetLength is the maximum number of characters of a snippet
+	snippetLength = 200
+	sniffLength   = 512
+	minTermLength = 2
+	maxTermLength = 64
+)
+
+// document is an indexed file, keyed by its virtual path.
+type document struct {
+	Path     string
+	Size     int64
+	Modified int64
+	Terms    []string
+}
+
+// state is the persisted index.
+type state struct {
+	Next     int64
+	Docs     map[int64]*document
+	Paths    map[string]int64
+	Postings map[string]map[int64]bool
+	Updated  int64
+}
+
+var mtx = &sync.RWMutex{}
+var idx = newState()
+var cfg = &config.IndexConfig{}
+var scanMtx = &sync.Mutex{}
+var startOnce = &sync.Once{}
+
+func newState() *state {
+	return &state{Docs: make(map[int64]*document), Paths: make(map[string]int64),
+		Postings: make(map[string]map[int64]bool)}
+}
+
+// Configure loads the persisted index and starts the periodic scans.
+func Configure(indexConfig *config.IndexConfig) error {
+	mtx.Lock()
+	cfg = indexConfig
+	mtx.Unlock()
+	if !indexConfig.Enabled {
+		return nil
+	}
+	err := os.MkdirAll(indexConfig.Dir, 0700)
+	if err != nil {
+		return err
+	}
+	loaded, err := load(filepath.Join(indexConfig.Dir, FileName))
+	if err == nil {
+		mtx.Lock()
+		idx = loaded
+		mtx.Unlock()
+	} else if !os.IsNotExist(err) {
+		// A corrupted index is rebuilt by the next scan
+		os.Remove(filepath.Join(indexConfig.Dir, FileName))
+	}
+	startOnce.Do(func() {
+		go scanLoop()
+	})
+	return nil
+}
+
+// Enabled returns true when the full text index is maintained.
+func Enabled() bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return cfg.Enabled
+}
+
+func scanLoop() {
+	for {
+		Scan()
+		mtx.RLock()
+		interval := time.Duration(cfg.Interval) * time.Second
+		mtx.RUnlock()
+		if interval <= 0 {
+			interval = 5 * time.Minute
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Scan brings the index up to date with the files of the shares and
+// persists it if anything changed.
+func Scan() {
+	scanMtx.Lock()
+	defer scanMtx.Unlock()
+	mtx.RLock()
+	maxFileSize, dir := cfg.MaxFileSize, cfg.Dir
+	mtx.RUnlock()
+
+	seen := make(map[string]bool)
+	changed := false
+	for _, share := range shares.List() {
+		trashDir := filepath.Join(share.RealRoot(), trash.Dir)
+		filepath.WalkDir(share.RealRoot(), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if path == trashDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil || (maxFileSize > 0 && info.Size() > maxFileSize) {
+				return nil
+			}
+			rel, err := filepath.Rel(share.RealRoot(), path)
+			if err != nil {
+				return nil
+			}
+			virtualPath := shares.Clean(share.Name + "/" + filepath.ToSlash(rel))
+			seen[virtualPath] = true
+			if current(virtualPath, info) {
+				return nil
+			}
+			terms, err := readTerms(path)
+			if err != nil {
+				// Binary or unreadable, do not keep a previous version of it
+				seen[virtualPath] = false
+				return nil
+			}
+			update(&document{Path: virtualPath, Size: info.Size(), Modified: info.ModTime().UnixNano(), Terms: terms})
+			changed = true
+			return nil
+		})
+	}
+
+	mtx.Lock()
+	for path, id := range idx.Paths {
+		if !seen[path] {
+			idx.remove(id)
+			changed = true
+		}
+	}
+	idx.Updated = time.Now().Unix()
+	mtx.Unlock()
+
+	if changed && dir != "" {
+		mtx.RLock()
+		err := save(filepath.Join(dir, FileName), idx)
+		mtx.RUnlock()
+		if err != nil {
+			os.Remove(filepath.Join(dir, FileName) + ".tmp")
+		}
+	}
+}
+
+// current returns true when the indexed version of the file is up to date.
+func current(virtualPath string, info fs.FileInfo) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	id, ok := idx.Paths[virtualPath]
+	if !ok {
+		return false
+	}
+	doc := idx.Docs[id]
+	return doc.Size == info.Size() && doc.Modified == info.ModTime().UnixNano()
+}
+
+// update replaces the indexed version of the document.
+func update(doc *document) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	if id, ok := idx.Paths[doc.Path]; ok {
+		idx.remove(id)
+	}
+	idx.Next++
+	id := idx.Next
+	idx.Docs[id] = doc
+	idx.Paths[doc.Path] = id
+	for _, term := range doc.Terms {
+		posting, ok := idx.Postings[term]
+		if !ok {
+			posting = make(map[int64]bool)
+			idx.Postings[term] = posting
+		}
+		posting[id] = true
+	}
+}
+
+func (this *state) remove(id int64) {
+	doc, ok := this.Docs[id]
+	if !ok {
+		return
+	}
+	for _, term := range doc.Terms {
+		posting := this.Postings[term]
+		delete(posting, id)
+		if len(posting) == 0 {
+			delete(this.Postings, term)
+		}
+	}
+	delete(this.Paths, doc.Path)
+	delete(this.Docs, id)
+}
+
+// readTerms returns the distinct terms of a text file, or an error if the
+// file looks binary.
+func readTerms(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	head, err := reader.Peek(sniffLength)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if !IsText(head) {
+		return nil, errors.New("Binary file")
+	}
+	terms := make(map[string]bool)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		for _, term := range Terms(scanner.Text()) {
+			terms[term] = true
+		}
+	}
+	if scanner.Err() != nil {
+		return nil, scanner.Err()
+	}
+	list := make([]string, 0, len(terms))
+	for term := range terms {
+		list = append(list, term)
+	}
+	return list, nil
+}
+
+// IsText sniffs the head of a file, text has no NUL bytes and is valid UTF-8,
+// except for a rune cut at the end of the head.
+func IsText(head []byte) bool {
+	if bytes.IndexByte(head, 0) >= 0 {
+		return false
+	}
+	for len(head) > 0 {
+		r, size := utf8.DecodeRune(head)
+		if r == utf8.RuneError && size == 1 {
+			return len(head) < utf8.UTFMax && !utf8.FullRune(head)
+		}
+		head = head[size:]
+	}
+	return true
+}
+
+// Terms splits text to lower case words of letters and digits.
+func Terms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := words[:0]
+	for _, word := range words {
+		length := utf8.RuneCountInString(word)
+		if length >= minTermLength && length <= maxTermLength {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// Query returns the files under the query path containing all the terms of
+// the query, with the lines they appear in.
+func Query(query *files.ContentQuery) (*files.ContentResult, error) {
+	terms := Terms(query.Query)
+	if len(terms) == 0 {
+		return nil, errors.New("Query '" + query.Query + "' has no searchable terms")
+	}
+	under := "/"
+	if query.Path != "" {
+		under = shares.Clean(query.Path)
+	}
+	limit := int(query.Limit)
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	mtx.RLock()
+	// Intersect starting from the rarest term
+	sort.Slice(terms, func(i, j int) bool {
+		return len(idx.Postings[terms[i]]) < len(idx.Postings[terms[j]])
+	})
+	paths := make([]string, 0)
+	for id := range idx.Postings[terms[0]] {
+		all := true
+		for _, term := range terms[1:] {
+			if !idx.Postings[term][id] {
+				all = false
+				break
+			}
+		}
+		if all && (under == "/" || shares.IsWithin(idx.Docs[id].Path, under)) {
+			paths = append(paths, idx.Docs[id].Path)
+		}
+	}
+	result := &files.ContentResult{Total: int32(len(paths)), Documents: int64(len(idx.Docs)), Updated: idx.Updated}
+	mtx.RUnlock()
+
+	sort.Strings(paths)
+	if len(paths) > limit {
+		paths = paths[:limit]
+		result.Truncated = true
+	}
+	result.Hits = make([]*files.ContentHit, 0, len(paths))
+	for _, path := range paths {
+		realPath, err := shares.Resolve(path)
+		if err != nil {
+			continue
+		}
+		info, err := os.Stat(realPath)
+		if err != nil {
+			continue
+		}
+		hit := &files.ContentHit{File: &files.File{Path: filepath.Dir(path), Name: filepath.Base(path),
+			Size: info.Size(), Modified: info.ModTime().Unix()}}
+		hit.Snippets = snippets(realPath, terms)
+		result.Hits = append(result.Hits, hit)
+	}
+	return result, nil
+}
+
+// snippets returns the first lines of the file that contain any of the terms.
+func snippets(path string, terms []string) []*files.ContentSnippet {
+	list := make([]*files.ContentSnippet, 0)
+	file, err := os.Open(path)
+	if err != nil {
+		return list
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for line := int32(1); scanner.Scan() && len(list) < MaxSnippets; line++ {
+		text := scanner.Text()
+		lower := strings.ToLower(text)
+		for _, term := range terms {
+			if strings.Contains(lower, term) {
+				list = append(list, &files.ContentSnippet{Line: line, Text: trim(text)})
+				break
+			}
+		}
+	}
+	return list
+}
+
+func trim(text string) string {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) <= snippetLength {
+		return text
+	}
+	return string([]rune(text)[:snippetLength]) + "…"
+}
+
+func load(filename string) (*state, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	loaded := newState()
+	err = gob.NewDecoder(bufio.NewReader(file)).Decode(loaded)
+	if err != nil {
+		return nil, err
+	}
+	// gob leaves the maps that were empty when saved nil
+	if loaded.Docs == nil || loaded.Paths == nil || loaded.Postings == nil {
+		empty := newState()
+		empty.Next = loaded.Next
+		return empty, nil
+	}
+	return loaded, nil
+}
+
+func save(filename string, s *state) error {
+	file, err := os.OpenFile(filename+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	err = gob.NewEncoder(writer).Encode(s)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(filename+".tmp", filename)
+}
@@ -0,0 +1,98 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8web"
+	"github.com/saichler/l8utils/go/utils/web"
+)
+
+const (
+	ServiceName = "Index"
+	ServiceType = "IndexService"
+	ServiceArea = byte(0)
+)
+
+type IndexService struct {
+	sla *ifs.ServiceLevelAgreement
+}
+
+func Activate(vnic ifs.IVNic) {
+	sla := ifs.NewServiceLevelAgreement(&IndexService{}, ServiceName, ServiceArea, false, nil)
+	ws := web.New(ServiceName, ServiceArea, 0)
+	ws.AddEndpoint(&files.ContentQuery{}, ifs.POST, &files.ContentResult{})
+	sla.SetWebService(ws)
+	vnic.Resources().Services().Activate(sla, vnic)
+}
+
+func (this *IndexService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	vnic.Resources().Registry().Register(&files.File{})
+	vnic.Resources().Registry().Register(&files.ContentQuery{})
+	vnic.Resources().Registry().Register(&files.ContentResult{})
+	vnic.Resources().Registry().Register(&l8web.L8Empty{})
+	this.sla = sla
+	return nil
+}
+
+func (this *IndexService) DeActivate() error {
+	return nil
+}
+
+// Post returns the files containing all the words of the query.
+func (this *IndexService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	query, ok := pb.Element().(*files.ContentQuery)
+	if !ok {
+		return object.New(nil, &l8web.L8Empty{})
+	}
+	if !Enabled() {
+		return object.NewError("The full text index is disabled")
+	}
+	result, err := Query(query)
+	if err != nil {
+		return object.NewError(err.Error())
+	}
+	return object.New(nil, result)
+}
+
+func (this *IndexService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *IndexService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *IndexService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+
+func (this *IndexService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *IndexService) GetCopy(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *IndexService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+func (this *IndexService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *IndexService) WebService() ifs.IWebService {
+	return this.sla.WebService()
+}
@@ -0,0 +1,176 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobs
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/saichler/l8nasfile/go/nas/registry"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8web"
+	"github.com/saichler/l8utils/go/utils/web"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ServiceName = "Jobs"
+	ServiceType = "JobService"
+	ServiceArea = byte(0)
+	// MaxRequestSize caps the body of a job request
+	MaxRequestSize = 1 << 16
+)
+
+type JobService struct {
+	sla *ifs.ServiceLevelAgreement
+}
+
+func Activate(vnic ifs.IVNic) {
+	sla := ifs.NewServiceLevelAgreement(&JobService{}, ServiceName, ServiceArea, false, nil)
+	ws := web.New(ServiceName, ServiceArea, 0)
+	ws.AddEndpoint(&files.JobRequest{}, ifs.POST, &files.JobList{})
+	sla.SetWebService(ws)
+	vnic.Resources().Services().Activate(sla, vnic)
+}
+
+func (this *JobService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	vnic.Resources().Registry().Register(&files.Job{})
+	vnic.Resources().Registry().Register(&files.JobList{})
+	vnic.Resources().Registry().Register(&files.JobRequest{})
+	vnic.Resources().Registry().Register(&l8web.L8Empty{})
+	this.sla = sla
+	return nil
+}
+
+func (this *JobService) DeActivate() error {
+	return nil
+}
+
+// Post answers the job request of the service callers, which are not
+// identified, so they only see the jobs they started through the service.
+func (this *JobService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	req, ok := pb.Element().(*files.JobRequest)
+	if !ok {
+		return object.New(nil, &l8web.L8Empty{})
+	}
+	list, err := Request(req, "")
+	if err != nil {
+		return object.NewError(err.Error())
+	}
+	return object.New(nil, list)
+}
+
+// Request returns the job of the owner with the requested id, cancelling it
+// if requested, or all the running jobs and the history of the owner if no
+// id was given.
+func Request(req *files.JobRequest, owner string) (*files.JobList, error) {
+	if req.Id == "" {
+		return &files.JobList{Jobs: List(owner)}, nil
+	}
+	job := Get(req.Id, owner)
+	if job == nil {
+		return nil, errors.New("Job '" + req.Id + "' does not exist")
+	}
+	if req.Cancel {
+		Cancel(req.Id, owner)
+	}
+	return &files.JobList{Jobs: []*files.Job{job.Snapshot()}}, nil
+}
+
+// Handler answers the job requests posted by the authenticated user.
+func Handler(w http.ResponseWriter, r *http.Request, user string, resources ifs.IResources) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxRequestSize))
+	if err != nil {
+		http.Error(w, "Error reading request", http.StatusBadRequest)
+		return
+	}
+	req := &files.JobRequest{}
+	if len(body) > 0 {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, req)
+		if err != nil {
+			http.Error(w, "Invalid job request", http.StatusBadRequest)
+			return
+		}
+	}
+	list, err := Request(req, user)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	data, err := protojson.Marshal(list)
+	if err != nil {
+		resources.Logger().Error("Error encoding jobs: ", err)
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (this *JobService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *JobService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *JobService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+
+func (this *JobService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *JobService) GetCopy(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *JobService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+func (this *JobService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *JobService) WebService() ifs.IWebService {
+	return this.sla.WebService()
+}
+
+// EventsHandler streams the progress of the job of the user in the "id" query
+// parameter as server sent events, until the job is finished or the client
+// goes away.
+func EventsHandler(w http.ResponseWriter, r *http.Request, user string, resources ifs.IResources) {
+	job := Get(r.URL.Query().Get("id"), user)
+	if job == nil {
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+	var last *files.Job
+	registry.Stream(w, r, "job", job.Done(), func() (proto.Message, bool) {
+		snapshot := job.Snapshot()
+		if last != nil && proto.Equal(last, snapshot) {
+			return nil, false
+		}
+		last = snapshot
+		return snapshot, snapshot.Ended > 0
+	}, resources)
+}
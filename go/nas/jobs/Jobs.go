@@ -0,0 +1,217 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package jobs runs long running actions in the background, tracking their
+// progress and allowing to cancel them. Finished jobs are kept in a history
+// for the configured amount of time. Jobs belong to the user that started
+// them and are only visible to and cancellable by that user.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/registry"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrCancelled is returned by the job operations once the job was cancelled.
+var ErrCancelled = errors.New("Job was cancelled")
+
+type Job struct {
+	job    *files.Job
+	owner  string
+	mtx    *sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// jobs are the running jobs and the history, an hour long by default
+var jobs = registry.New(time.Hour)
+
+// Configure sets for how many seconds finished jobs are kept in the history.
+func Configure(historySeconds int64) {
+	jobs.SetKeep(time.Duration(historySeconds) * time.Second)
+}
+
+// Submit starts run in the background as a new job of the owner and returns it.
+func Submit(action files.ActionType, description, owner string, run func(job *Job) *files.ActionResponse) *Job {
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &Job{
+		job:    &files.Job{Id: registry.NewId(), Action: action, State: files.JobState_queued, Description: description},
+		owner:  owner,
+		mtx:    &sync.Mutex{},
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	jobs.Add(job.job.Id, job)
+
+	go func() {
+		job.mtx.Lock()
+		job.job.State = files.JobState_running
+		job.job.Started = time.Now().Unix()
+		job.mtx.Unlock()
+
+		resp, panicked := execute(job, run)
+
+		job.mtx.Lock()
+		job.job.Response = resp
+		job.job.Ended = time.Now().Unix()
+		if panicked {
+			job.job.State = files.JobState_failed
+		} else if job.ctx.Err() != nil {
+			job.job.State = files.JobState_cancelled
+		} else if resp != nil && resp.IsError {
+			job.job.State = files.JobState_failed
+		} else {
+			job.job.State = files.JobState_completed
+		}
+		job.mtx.Unlock()
+		job.cancel()
+		close(job.done)
+	}()
+	return job
+}
+
+// execute runs the job, a panic of run is recovered and returned as the
+// error response of the job, so it does not bring down the server.
+func execute(job *Job, run func(job *Job) *files.ActionResponse) (resp *files.ActionResponse, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			resp = &files.ActionResponse{IsError: true, Msg: fmt.Sprint("Job failed: ", r)}
+			panicked = true
+		}
+	}()
+	return run(job), false
+}
+
+// Get returns the job of the owner by its id, or nil if the owner has no
+// such job.
+func Get(id, owner string) *Job {
+	job, ok := jobs.Get(id).(*Job)
+	if !ok || job.owner != owner {
+		return nil
+	}
+	return job
+}
+
+// List returns a snapshot of the running jobs and the history of the owner,
+// latest first.
+func List(owner string) []*files.Job {
+	list := make([]*files.Job, 0)
+	for _, task := range jobs.List() {
+		if job := task.(*Job); job.owner == owner {
+			list = append(list, job.Snapshot())
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Started > list[j].Started
+	})
+	return list
+}
+
+// Cancel cancels the job of the owner, returns false if the owner has no
+// such job.
+func Cancel(id, owner string) bool {
+	job := Get(id, owner)
+	if job == nil {
+		return false
+	}
+	job.Cancel()
+	return true
+}
+
+// Cancel cancels the job.
+func (this *Job) Cancel() {
+	this.cancel()
+}
+
+// Ended returns when the job finished, the zero time while it runs.
+func (this *Job) Ended() time.Time {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	if this.job.Ended == 0 {
+		return time.Time{}
+	}
+	return time.Unix(this.job.Ended, 0)
+}
+
+func (this *Job) Id() string {
+	return this.job.Id
+}
+
+// Snapshot returns a copy of the current job state.
+func (this *Job) Snapshot() *files.Job {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	return proto.Clone(this.job).(*files.Job)
+}
+
+// Context is cancelled when the job is cancelled or finished.
+func (this *Job) Context() context.Context {
+	return this.ctx
+}
+
+// Err returns ErrCancelled once the job was cancelled.
+func (this *Job) Err() error {
+	select {
+	case <-this.done:
+		return nil
+	default:
+	}
+	if this.ctx.Err() != nil {
+		return ErrCancelled
+	}
+	return nil
+}
+
+// Wait blocks until the job is finished.
+func (this *Job) Wait() {
+	<-this.done
+}
+
+// Done is closed when the job is finished.
+func (this *Job) Done() <-chan struct{} {
+	return this.done
+}
+
+// AddTotals adds to the amount of work the job has to do.
+func (this *Job) AddTotals(bytes, files int64) {
+	this.mtx.Lock()
+	this.job.BytesTotal += bytes
+	this.job.FilesTotal += files
+	this.mtx.Unlock()
+}
+
+// AddBytes adds to the bytes the job has processed.
+func (this *Job) AddBytes(bytes int64) {
+	this.mtx.Lock()
+	this.job.BytesDone += bytes
+	this.mtx.Unlock()
+}
+
+// AddFiles adds to the files the job has processed.
+func (this *Job) AddFiles(files int64) {
+	this.mtx.Lock()
+	this.job.FilesDone += files
+	this.mtx.Unlock()
+}
@@ -0,0 +1,69 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/saichler/l8types/go/ifs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Stream sends the progress of a task as server sent events of the event
+// type, until the task is finished or the client goes away. next returns the
+// message to send, nil when there is nothing new, and whether the task is
+// finished. It is called every half a second and as soon as done is closed.
+func Stream(w http.ResponseWriter, r *http.Request, event string, done <-chan struct{},
+	next func() (proto.Message, bool), resources ifs.IResources) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		pb, finished := next()
+		if pb != nil {
+			data, err := protojson.Marshal(pb)
+			if err != nil {
+				resources.Logger().Error("Error encoding "+event+" event: ", err)
+				return
+			}
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+		if finished {
+			return
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-done:
+		case <-ticker.C:
+		}
+	}
+}
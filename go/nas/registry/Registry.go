@@ -0,0 +1,113 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package registry keeps the background tasks of a kind, like the jobs or
+// the searches, by a random id. A finished task is kept for a while, so its
+// result can still be fetched, and then purged.
+package registry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// Task is a background task kept by a registry.
+type Task interface {
+	// Ended returns when the task finished, the zero time while it runs
+	Ended() time.Time
+	// Cancel stops the task
+	Cancel()
+}
+
+type Registry struct {
+	mtx       *sync.Mutex
+	tasks     map[string]Task
+	keep      time.Duration
+	purgeOnce *sync.Once
+}
+
+// New returns a registry keeping the finished tasks for the keep duration.
+func New(keep time.Duration) *Registry {
+	return &Registry{mtx: &sync.Mutex{}, tasks: make(map[string]Task), keep: keep, purgeOnce: &sync.Once{}}
+}
+
+// SetKeep sets for how long the finished tasks are kept.
+func (this *Registry) SetKeep(keep time.Duration) {
+	this.mtx.Lock()
+	this.keep = keep
+	this.mtx.Unlock()
+}
+
+// Add keeps the task by its id and starts the purge of the registry.
+func (this *Registry) Add(id string, task Task) {
+	this.mtx.Lock()
+	this.tasks[id] = task
+	this.mtx.Unlock()
+	this.purgeOnce.Do(func() {
+		go this.purge()
+	})
+}
+
+// Get returns the task by its id, or nil if there is no such task.
+func (this *Registry) Get(id string) Task {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	return this.tasks[id]
+}
+
+// List returns all the tasks kept by the registry.
+func (this *Registry) List() []Task {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	list := make([]Task, 0, len(this.tasks))
+	for _, task := range this.tasks {
+		list = append(list, task)
+	}
+	return list
+}
+
+// Cancel stops the task, returns false if there is no such task.
+func (this *Registry) Cancel(id string) bool {
+	task := this.Get(id)
+	if task == nil {
+		return false
+	}
+	task.Cancel()
+	return true
+}
+
+// purge removes the finished tasks that were kept long enough.
+func (this *Registry) purge() {
+	for {
+		time.Sleep(time.Minute)
+		this.mtx.Lock()
+		for id, task := range this.tasks {
+			ended := task.Ended()
+			if !ended.IsZero() && time.Since(ended) > this.keep {
+				delete(this.tasks, id)
+			}
+		}
+		this.mtx.Unlock()
+	}
+}
+
+// NewId returns a random task id.
+func NewId() string {
+	buff := make([]byte, 8)
+	rand.Read(buff)
+	return hex.EncodeToString(buff)
+}
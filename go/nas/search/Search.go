@@ -0,0 +1,313 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package search finds the files and directories under a virtual path by
+// their name, size, modification time and type. A search walks the tree in
+// the background and collects its results incrementally, so clients can
+// fetch or stream them while the walk is still going on. Searches belong to
+// the user that started them and are only visible to that user.
+package search
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/registry"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultLimit is the number of results of a search without a limit
+	DefaultLimit = 1000
+	// MaxLimit caps the number of results of a single search
+	MaxLimit = 100000
+	// keep is how long a finished search is kept for its results to be fetched
+	keep = 10 * time.Minute
+)
+
+type Search struct {
+	id        string
+	owner     string
+	mtx       *sync.Mutex
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+	results   []*files.File
+	truncated bool
+	cancelled bool
+	err       string
+	ended     time.Time
+}
+
+var searches = registry.New(keep)
+
+// root is a directory to search, with its virtual path.
+type root struct {
+	realPath    string
+	virtualPath string
+	share       *shares.Share
+}
+
+type matcher struct {
+	name  string
+	glob  string
+	regex *regexp.Regexp
+	req   *files.SearchRequest
+}
+
+func newMatcher(req *files.SearchRequest) (*matcher, error) {
+	m := &matcher{name: strings.ToLower(req.Name), glob: req.Glob, req: req}
+	if req.Glob != "" {
+		if _, err := filepath.Match(req.Glob, ""); err != nil {
+			return nil, errors.New("Invalid glob '" + req.Glob + "'")
+		}
+	}
+	if req.Regex != "" {
+		regex, err := regexp.Compile(req.Regex)
+		if err != nil {
+			return nil, errors.New("Invalid regex '" + req.Regex + "': " + err.Error())
+		}
+		m.regex = regex
+	}
+	if req.MaxSize > 0 && req.MinSize > req.MaxSize {
+		return nil, errors.New("Minimum size is larger than the maximum size")
+	}
+	return m, nil
+}
+
+// match returns true when the entry passes all the filters of the request.
+func (this *matcher) match(name string, info fs.FileInfo) bool {
+	switch this.req.EntryType {
+	case files.SearchEntryType_fileEntry:
+		if info.IsDir() {
+			return false
+		}
+	case files.SearchEntryType_directoryEntry:
+		if !info.IsDir() {
+			return false
+		}
+	}
+	if this.name != "" && !strings.Contains(strings.ToLower(name), this.name) {
+		return false
+	}
+	if this.glob != "" {
+		if ok, _ := filepath.Match(this.glob, name); !ok {
+			return false
+		}
+	}
+	if this.regex != nil && !this.regex.MatchString(name) {
+		return false
+	}
+	if this.req.MinSize > 0 && info.Size() < this.req.MinSize {
+		return false
+	}
+	if this.req.MaxSize > 0 && info.Size() > this.req.MaxSize {
+		return false
+	}
+	modified := info.ModTime().Unix()
+	if this.req.ModifiedAfter > 0 && modified < this.req.ModifiedAfter {
+		return false
+	}
+	if this.req.ModifiedBefore > 0 && modified > this.req.ModifiedBefore {
+		return false
+	}
+	return true
+}
+
+// Start validates the request and starts the search of the owner in the
+// background. The top level path "/" searches all the shares.
+func Start(req *files.SearchRequest, owner string) (*Search, error) {
+	m, err := newMatcher(req)
+	if err != nil {
+		return nil, err
+	}
+	roots := make([]root, 0)
+	if shares.IsRoot(req.Path) {
+		for _, share := range shares.List() {
+			roots = append(roots, root{realPath: share.RealRoot(), virtualPath: "/" + share.Name, share: share})
+		}
+	} else {
+		share, _, err := shares.Split(req.Path)
+		if err != nil {
+			return nil, err
+		}
+		realPath, err := shares.Resolve(req.Path)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, root{realPath: realPath, virtualPath: shares.Clean(req.Path), share: share})
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	search := &Search{id: registry.NewId(), owner: owner, mtx: &sync.Mutex{}, ctx: ctx, cancel: cancel,
+		done: make(chan struct{}), results: make([]*files.File, 0)}
+	searches.Add(search.id, search)
+
+	go func() {
+		for _, r := range roots {
+			if !search.walk(r, m, limit) {
+				break
+			}
+		}
+		search.mtx.Lock()
+		search.ended = time.Now()
+		search.mtx.Unlock()
+		search.cancel()
+		close(search.done)
+	}()
+	return search, nil
+}
+
+// walk adds the matching entries under the root, returning false once the
+// search was cancelled or reached its limit.
+func (this *Search) walk(r root, m *matcher, limit int) bool {
+	trashDir := filepath.Join(r.share.RealRoot(), trash.Dir)
+	more := true
+	err := filepath.WalkDir(r.realPath, func(path string, d fs.DirEntry, err error) error {
+		if this.ctx.Err() != nil {
+			more = false
+			return filepath.SkipAll
+		}
+		if err != nil || path == r.realPath {
+			return nil
+		}
+		if path == trashDir {
+			return filepath.SkipDir
+		}
+		info, err := d.Info()
+		if err != nil || !m.match(d.Name(), info) {
+			return nil
+		}
+		rel, err := filepath.Rel(r.realPath, filepath.Dir(path))
+		if err != nil {
+			return nil
+		}
+		file := &files.File{
+			Name:        d.Name(),
+			Path:        shares.Clean(r.virtualPath + "/" + filepath.ToSlash(rel)),
+			IsDirectory: d.IsDir(),
+			Size:        info.Size(),
+			Modified:    info.ModTime().Unix(),
+		}
+		this.mtx.Lock()
+		this.results = append(this.results, file)
+		full := len(this.results) >= limit
+		this.truncated = full
+		this.mtx.Unlock()
+		if full {
+			more = false
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		this.mtx.Lock()
+		this.err = err.Error()
+		this.mtx.Unlock()
+		return false
+	}
+	return more
+}
+
+// Get returns the search of the owner by its id, or nil if the owner has no
+// such search.
+func Get(id, owner string) *Search {
+	search, ok := searches.Get(id).(*Search)
+	if !ok || search.owner != owner {
+		return nil
+	}
+	return search
+}
+
+// Cancel stops the search of the owner, returns false if the owner has no
+// such search.
+func Cancel(id, owner string) bool {
+	search := Get(id, owner)
+	if search == nil {
+		return false
+	}
+	search.Cancel()
+	return true
+}
+
+// Cancel stops the search, it is marked cancelled unless it already ended.
+func (this *Search) Cancel() {
+	this.mtx.Lock()
+	if this.ended.IsZero() {
+		this.cancelled = true
+	}
+	this.mtx.Unlock()
+	this.cancel()
+}
+
+// Ended returns when the search finished, the zero time while it runs.
+func (this *Search) Ended() time.Time {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	return this.ended
+}
+
+func (this *Search) Id() string {
+	return this.id
+}
+
+// Result returns the results found so far starting at offset.
+func (this *Search) Result(offset int) *files.SearchResult {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	if offset < 0 || offset > len(this.results) {
+		offset = len(this.results)
+	}
+	result := &files.SearchResult{
+		Id:        this.id,
+		Offset:    int32(offset),
+		Total:     int32(len(this.results)),
+		Done:      !this.ended.IsZero(),
+		Truncated: this.truncated,
+		Cancelled: this.cancelled,
+		Error:     this.err,
+		Files:     make([]*files.File, 0, len(this.results)-offset),
+	}
+	for _, file := range this.results[offset:] {
+		result.Files = append(result.Files, proto.Clone(file).(*files.File))
+	}
+	return result
+}
+
+// Wait blocks until the search is finished.
+func (this *Search) Wait() {
+	<-this.done
+}
+
+// Done is closed when the search is finished.
+func (this *Search) Done() <-chan struct{} {
+	return this.done
+}
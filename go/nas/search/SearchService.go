@@ -0,0 +1,184 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/saichler/l8nasfile/go/nas/registry"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8web"
+	"github.com/saichler/l8utils/go/utils/web"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ServiceName = "Search"
+	ServiceType = "SearchService"
+	ServiceArea = byte(0)
+	// MaxRequestSize caps the body of a search request
+	MaxRequestSize = 1 << 16
+)
+
+type SearchService struct {
+	sla *ifs.ServiceLevelAgreement
+}
+
+func Activate(vnic ifs.IVNic) {
+	sla := ifs.NewServiceLevelAgreement(&SearchService{}, ServiceName, ServiceArea, false, nil)
+	ws := web.New(ServiceName, ServiceArea, 0)
+	ws.AddEndpoint(&files.SearchRequest{}, ifs.POST, &files.SearchResult{})
+	sla.SetWebService(ws)
+	vnic.Resources().Services().Activate(sla, vnic)
+}
+
+func (this *SearchService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	vnic.Resources().Registry().Register(&files.File{})
+	vnic.Resources().Registry().Register(&files.SearchRequest{})
+	vnic.Resources().Registry().Register(&files.SearchResult{})
+	vnic.Resources().Registry().Register(&l8web.L8Empty{})
+	this.sla = sla
+	return nil
+}
+
+func (this *SearchService) DeActivate() error {
+	return nil
+}
+
+// Post answers the search request of the service callers, which are not
+// identified, so they only see the searches they started through the service.
+func (this *SearchService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	req, ok := pb.Element().(*files.SearchRequest)
+	if !ok {
+		return object.New(nil, &l8web.L8Empty{})
+	}
+	result, err := Request(req, "")
+	if err != nil {
+		return object.NewError(err.Error())
+	}
+	return object.New(nil, result)
+}
+
+// Request starts a new search of the owner when there is no id, otherwise it
+// returns the results of the search of the owner from the requested offset on,
+// cancelling it if requested. With wait the results are returned once the
+// search is finished.
+func Request(req *files.SearchRequest, owner string) (*files.SearchResult, error) {
+	var search *Search
+	if req.Id == "" {
+		var err error
+		search, err = Start(req, owner)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		search = Get(req.Id, owner)
+		if search == nil {
+			return nil, errors.New("Search '" + req.Id + "' does not exist")
+		}
+		if req.Cancel {
+			search.Cancel()
+		}
+	}
+	if req.Wait || req.Cancel {
+		search.Wait()
+	}
+	return search.Result(int(req.Offset)), nil
+}
+
+// Handler answers the search requests posted by the authenticated user.
+func Handler(w http.ResponseWriter, r *http.Request, user string, resources ifs.IResources) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxRequestSize))
+	if err != nil {
+		http.Error(w, "Error reading request", http.StatusBadRequest)
+		return
+	}
+	req := &files.SearchRequest{}
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, req)
+	if err != nil {
+		http.Error(w, "Invalid search request", http.StatusBadRequest)
+		return
+	}
+	result, err := Request(req, user)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := protojson.Marshal(result)
+	if err != nil {
+		resources.Logger().Error("Error encoding search results: ", err)
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (this *SearchService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *SearchService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *SearchService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+
+func (this *SearchService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *SearchService) GetCopy(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *SearchService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+func (this *SearchService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *SearchService) WebService() ifs.IWebService {
+	return this.sla.WebService()
+}
+
+// EventsHandler streams the results of the search of the user in the "id"
+// query parameter as server sent events, each event carrying the files found
+// since the previous one, until the search is finished or the client goes away.
+func EventsHandler(w http.ResponseWriter, r *http.Request, user string, resources ifs.IResources) {
+	search := Get(r.URL.Query().Get("id"), user)
+	if search == nil {
+		http.Error(w, "Search not found", http.StatusNotFound)
+		return
+	}
+	offset := 0
+	registry.Stream(w, r, "results", search.Done(), func() (proto.Message, bool) {
+		result := search.Result(offset)
+		if len(result.Files) == 0 && !result.Done {
+			return nil, false
+		}
+		offset = int(result.Total)
+		return result, result.Done
+	}, resources)
+}
@@ -23,7 +23,18 @@ import (
 	"github.com/saichler/l8bus/go/overlay/vnet"
 	"github.com/saichler/l8bus/go/overlay/vnic"
 	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/archives"
+	"github.com/saichler/l8nasfile/go/nas/config"
 	files2 "github.com/saichler/l8nasfile/go/nas/files"
+	"github.com/saichler/l8nasfile/go/nas/hashes"
+	"github.com/saichler/l8nasfile/go/nas/index"
+	"github.com/saichler/l8nasfile/go/nas/jobs"
+	"github.com/saichler/l8nasfile/go/nas/search"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/sizes"
+	"github.com/saichler/l8nasfile/go/nas/thumbnails"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/nas/usage"
 	"github.com/saichler/l8nasfile/go/types/files"
 	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8utils/go/utils/shared"
@@ -32,6 +43,28 @@ import (
 
 func Start() {
 	server.Timeout = 600
+	cfg, err := config.Load(config.FileName)
+	if err != nil {
+		panic(err)
+	}
+	err = shares.Configure(cfg.Shares)
+	if err != nil {
+		panic(err)
+	}
+	jobs.Configure(cfg.Jobs.History)
+	trash.Configure(cfg.Trash)
+	err = index.Configure(cfg.Index)
+	if err != nil {
+		panic(err)
+	}
+	actions.ConfigureAuthorization(cfg.Authorization)
+	actions.ConfigureArchives(cfg.Archive)
+	archives.Configure(cfg.Archive)
+	err = thumbnails.Configure(cfg.Thumbnails)
+	if err != nil {
+		panic(err)
+	}
+
 	vnetPort := uint32(15151)
 	r := shared.ResourcesOf("vnet-nas", vnetPort, 0, "")
 	r.Logger().SetLogLevel(ifs.Info_Level)
@@ -41,10 +74,10 @@ func Start() {
 	r.Logger().Info("vnet started!")
 	r.Logger().SetLogLevel(ifs.Error_Level)
 	time.Sleep(time.Second)
-	startWebServer(3443, vnetPort, "files")
+	startWebServer(3443, vnetPort, "files", cfg)
 }
 
-func startWebServer(port int, vnetPort uint32, cert string) {
+func startWebServer(port int, vnetPort uint32, cert string, cfg *config.Config) {
 	serverConfig := &server.RestServerConfig{
 		Host:           ipsegment.MachineIP,
 		Port:           port,
@@ -63,6 +96,18 @@ func startWebServer(port int, vnetPort uint32, cert string) {
 	r.Registry().Register(&files.FileList{})
 	r.Registry().Register(&files.Action{})
 	r.Registry().Register(&files.ActionResponse{})
+	r.Registry().Register(&files.JobRequest{})
+	r.Registry().Register(&files.JobList{})
+	r.Registry().Register(&files.SearchRequest{})
+	r.Registry().Register(&files.SearchResult{})
+	r.Registry().Register(&files.ContentQuery{})
+	r.Registry().Register(&files.ContentResult{})
+	r.Registry().Register(&files.SizeRequest{})
+	r.Registry().Register(&files.SizeResult{})
+	r.Registry().Register(&files.UsageRequest{})
+	r.Registry().Register(&files.UsageReport{})
+	r.Registry().Register(&files.HashRequest{})
+	r.Registry().Register(&files.HashResult{})
 
 	nic := vnic.NewVirtualNetworkInterface(r, nil)
 	nic.Resources().SysConfig().KeepAliveIntervalSeconds = 0
@@ -71,34 +116,179 @@ func startWebServer(port int, vnetPort uint32, cert string) {
 
 	files2.Activate(nic)
 	actions.Activate(nic)
+	jobs.Activate(nic)
+	search.Activate(nic)
+	index.Activate(nic)
+	sizes.Activate(nic)
+	usage.Activate(nic)
+	hashes.Activate(nic)
 
 	//Activate the webpoints service
 	sla := ifs.NewServiceLevelAgreement(&server.WebService{}, ifs.WebService, 0, false, nil)
 	sla.SetArgs(svr, nic)
 	nic.Resources().Services().Activate(sla, nic)
 
+	// Register streaming listing endpoint
+	registerStreamEndpoint(nic)
+
 	// Register download endpoint
 	registerDownloadEndpoint(nic)
 
+	// Register thumbnail endpoint
+	registerThumbnailEndpoint(nic)
+
+	// Register text preview and edit endpoint
+	registerTextEndpoint(nic)
+
+	// Register upload endpoints
+	registerUploadEndpoint(nic, cfg.Upload)
+	registerUploadSessionsEndpoint(nic, cfg.Upload)
+
+	// Register the actions and jobs endpoints of the authenticated user
+	registerActionEndpoint(nic)
+	registerJobEndpoint(nic)
+
+	// Register job progress events endpoint
+	registerJobEventsEndpoint(nic)
+
+	// Register the search and search results events endpoints of the authenticated user
+	registerSearchEndpoint(nic)
+	registerSearchEventsEndpoint(nic)
+
 	nic.Resources().Logger().Info("Web Server Started!")
 
 	svr.Start()
 }
 
+func registerStreamEndpoint(vnic ifs.IVNic) {
+	http.HandleFunc("/files/list/stream", func(w http.ResponseWriter, r *http.Request) {
+		if !authenticated(w, r, vnic) {
+			return
+		}
+		files2.StreamHandler(w, r, vnic.Resources())
+	})
+}
+
 func registerDownloadEndpoint(vnic ifs.IVNic) {
 	http.HandleFunc("/files/download", func(w http.ResponseWriter, r *http.Request) {
-		// Check authentication
-		bearer := r.Header.Get("Authorization")
-		if bearer == "" {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		if !authenticated(w, r, vnic) {
+			return
+		}
+		actions.DownloadHandler(w, r, vnic.Resources())
+	})
+}
+
+func registerThumbnailEndpoint(vnic ifs.IVNic) {
+	http.HandleFunc("/files/thumbnail", func(w http.ResponseWriter, r *http.Request) {
+		if !authenticated(w, r, vnic) {
+			return
+		}
+		thumbnails.Handler(w, r, vnic.Resources())
+	})
+}
+
+func registerTextEndpoint(vnic ifs.IVNic) {
+	http.HandleFunc("/files/text", func(w http.ResponseWriter, r *http.Request) {
+		if !authenticated(w, r, vnic) {
+			return
+		}
+		actions.TextHandler(w, r, vnic.Resources())
+	})
+}
+
+func registerUploadEndpoint(vnic ifs.IVNic, cfg *config.UploadConfig) {
+	http.HandleFunc("/files/upload", func(w http.ResponseWriter, r *http.Request) {
+		if !authenticated(w, r, vnic) {
 			return
 		}
-		_, ok := vnic.Resources().Security().ValidateToken(bearer, vnic)
+		actions.UploadHandler(w, r, vnic.Resources(), cfg)
+	})
+}
+
+func registerUploadSessionsEndpoint(vnic ifs.IVNic, cfg *config.UploadConfig) {
+	sessions, err := actions.NewUploadSessions(cfg)
+	if err != nil {
+		panic(err)
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if !authenticated(w, r, vnic) {
+			return
+		}
+		sessions.Handler(w, r, vnic.Resources())
+	}
+	http.HandleFunc(actions.SessionsEndpoint, handler)
+	http.HandleFunc(actions.SessionsEndpoint+"/", handler)
+}
+
+func registerActionEndpoint(vnic ifs.IVNic) {
+	http.HandleFunc("/files/actions", func(w http.ResponseWriter, r *http.Request) {
+		user, ok := authenticatedUser(w, r, vnic)
 		if !ok {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
+		actions.ActionHandler(w, r, user, vnic.Resources())
+	})
+}
 
-		actions.DownloadHandler(w, r, vnic.Resources())
+func registerJobEndpoint(vnic ifs.IVNic) {
+	http.HandleFunc("/files/jobs", func(w http.ResponseWriter, r *http.Request) {
+		user, ok := authenticatedUser(w, r, vnic)
+		if !ok {
+			return
+		}
+		jobs.Handler(w, r, user, vnic.Resources())
 	})
 }
+
+func registerJobEventsEndpoint(vnic ifs.IVNic) {
+	http.HandleFunc("/files/jobs/events", func(w http.ResponseWriter, r *http.Request) {
+		user, ok := authenticatedUser(w, r, vnic)
+		if !ok {
+			return
+		}
+		jobs.EventsHandler(w, r, user, vnic.Resources())
+	})
+}
+
+func registerSearchEndpoint(vnic ifs.IVNic) {
+	http.HandleFunc("/files/search", func(w http.ResponseWriter, r *http.Request) {
+		user, ok := authenticatedUser(w, r, vnic)
+		if !ok {
+			return
+		}
+		search.Handler(w, r, user, vnic.Resources())
+	})
+}
+
+func registerSearchEventsEndpoint(vnic ifs.IVNic) {
+	http.HandleFunc("/files/search/events", func(w http.ResponseWriter, r *http.Request) {
+		user, ok := authenticatedUser(w, r, vnic)
+		if !ok {
+			return
+		}
+		search.EventsHandler(w, r, user, vnic.Resources())
+	})
+}
+
+// authenticated validates the bearer token of the request, replying with
+// Unauthorized if it is missing or invalid.
+func authenticated(w http.ResponseWriter, r *http.Request, vnic ifs.IVNic) bool {
+	_, ok := authenticatedUser(w, r, vnic)
+	return ok
+}
+
+// authenticatedUser validates the bearer token of the request and returns
+// the user it was issued to.
+func authenticatedUser(w http.ResponseWriter, r *http.Request, vnic ifs.IVNic) (string, bool) {
+	bearer := r.Header.Get("Authorization")
+	if bearer == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return "", false
+	}
+	user, ok := vnic.Resources().Security().ValidateToken(bearer, vnic)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return "", false
+	}
+	return user, true
+}
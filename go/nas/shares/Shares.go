@@ -0,0 +1,271 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package shares maps the virtual paths the clients see, "/<share>/<sub path>",
+// to real paths confined under the share root directories.
+package shares
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+type Share struct {
+	Name string `json:"name"`
+	Root string `json:"root"`
+	// realRoot is Root with all symbolic links resolved
+	realRoot string
+}
+
+var mtx = &sync.RWMutex{}
+var shareList = make([]*Share, 0)
+var shareMap = make(map[string]*Share)
+
+// reserved are the directories under every share root that the server
+// manages itself, they cannot be reached through a virtual path.
+var reserved = make(map[string]bool)
+
+// Configure replaces the configured shares, every root must be an existing directory.
+func Configure(list []*Share) error {
+	newList := make([]*Share, 0, len(list))
+	newMap := make(map[string]*Share)
+	for _, share := range list {
+		if share.Name == "" || strings.Contains(share.Name, "/") || share.Name == "." || share.Name == ".." {
+			return errors.New("Invalid share name '" + share.Name + "'")
+		}
+		if _, exist := newMap[share.Name]; exist {
+			return errors.New("Duplicate share name '" + share.Name + "'")
+		}
+		if !filepath.IsAbs(share.Root) {
+			return errors.New("Share '" + share.Name + "' root '" + share.Root + "' is not an absolute path")
+		}
+		realRoot, err := filepath.EvalSymlinks(share.Root)
+		if err != nil {
+			return errors.New("Share '" + share.Name + "' root: " + err.Error())
+		}
+		info, err := os.Stat(realRoot)
+		if err != nil || !info.IsDir() {
+			return errors.New("Share '" + share.Name + "' root '" + share.Root + "' is not a directory")
+		}
+		s := &Share{Name: share.Name, Root: filepath.Clean(share.Root), realRoot: realRoot}
+		newList = append(newList, s)
+		newMap[s.Name] = s
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	shareList = newList
+	shareMap = newMap
+	return nil
+}
+
+// Reserve hides the directory name under every share root from the virtual
+// paths, Resolve refuses the paths inside it.
+func Reserve(name string) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	reserved[name] = true
+}
+
+// isReserved returns true if the real path is inside a reserved directory
+// of the share.
+func (this *Share) isReserved(realPath string) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	for name := range reserved {
+		if IsWithin(realPath, filepath.Join(this.realRoot, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReserved returns true if the real path is inside a reserved directory of
+// any share.
+func IsReserved(realPath string) bool {
+	for _, share := range List() {
+		if share.isReserved(realPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the configured shares in configuration order.
+func List() []*Share {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	result := make([]*Share, len(shareList))
+	copy(result, shareList)
+	return result
+}
+
+// Get returns the share by its name or nil.
+func Get(name string) *Share {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return shareMap[name]
+}
+
+// RealRoot returns the share root with its symbolic links resolved.
+func (this *Share) RealRoot() string {
+	return this.realRoot
+}
+
+// Clean normalizes a virtual path to an absolute, slash separated form.
+func Clean(virtualPath string) string {
+	return filepath.Clean("/" + virtualPath)
+}
+
+// IsRoot returns true when the virtual path is the top level listing of the shares.
+func IsRoot(virtualPath string) bool {
+	return Clean(virtualPath) == "/"
+}
+
+// IsShareRoot returns true when the virtual path is the root directory of a share.
+func IsShareRoot(virtualPath string) bool {
+	_, rel, err := Split(virtualPath)
+	return err == nil && rel == ""
+}
+
+// VirtualPath returns the virtual path of a files.File.
+func VirtualPath(f *files.File) string {
+	return Clean(f.Path + "/" + f.Name)
+}
+
+// Split returns the share of a virtual path and the path relative to its root.
+func Split(virtualPath string) (*Share, string, error) {
+	clean := Clean(virtualPath)
+	if clean == "/" {
+		return nil, "", errors.New("Path '/' is not inside a share")
+	}
+	parts := strings.SplitN(clean[1:], "/", 2)
+	share := Get(parts[0])
+	if share == nil {
+		return nil, "", errors.New("Share '" + parts[0] + "' does not exist")
+	}
+	if len(parts) == 1 {
+		return share, "", nil
+	}
+	return share, parts[1], nil
+}
+
+// Resolve maps a virtual path to the real path under its share root.
+// The path may not exist yet, but the existing part of it is resolved with
+// its symbolic links and must stay under the share root. The last element
+// is not followed when the path itself is a symbolic link, yet its target
+// must stay under the root as well. Paths inside the reserved directories
+// are refused, even through a link.
+func Resolve(virtualPath string) (string, error) {
+	share, rel, err := Split(virtualPath)
+	if err != nil {
+		return "", err
+	}
+	if rel == "" {
+		return share.realRoot, nil
+	}
+	realPath := filepath.Join(share.realRoot, rel)
+	parent, err := evalExisting(filepath.Dir(realPath))
+	if err != nil {
+		return "", err
+	}
+	if !IsWithin(parent, share.realRoot) {
+		return "", errors.New("Path '" + Clean(virtualPath) + "' escapes share '" + share.Name + "'")
+	}
+	realPath = filepath.Join(parent, filepath.Base(realPath))
+	target, err := evalExisting(realPath)
+	if err != nil {
+		return "", err
+	}
+	if !IsWithin(target, share.realRoot) {
+		return "", errors.New("Path '" + Clean(virtualPath) + "' escapes share '" + share.Name + "'")
+	}
+	if share.isReserved(realPath) || share.isReserved(target) {
+		return "", errors.New("Path '" + Clean(virtualPath) + "' is reserved")
+	}
+	return realPath, nil
+}
+
+// ResolveFile maps a files.File to its real path.
+func ResolveFile(f *files.File) (string, error) {
+	if f == nil {
+		return "", errors.New("File is nil")
+	}
+	return Resolve(VirtualPath(f))
+}
+
+// Virtual maps a real path under one of the share roots back to its virtual path.
+func Virtual(realPath string) (string, error) {
+	clean := filepath.Clean(realPath)
+	mtx.RLock()
+	defer mtx.RUnlock()
+	for _, share := range shareList {
+		if IsWithin(clean, share.realRoot) {
+			return Clean(share.Name + "/" + strings.TrimPrefix(clean, share.realRoot)), nil
+		}
+	}
+	return "", errors.New("Path '" + realPath + "' is not inside a share")
+}
+
+// IsWithin returns true if path is dir itself or is located under dir.
+func IsWithin(path, dir string) bool {
+	if path == dir {
+		return true
+	}
+	return strings.HasPrefix(path, strings.TrimSuffix(dir, "/")+"/")
+}
+
+// evalExisting resolves the symbolic links of the longest existing prefix
+// of path and appends the rest as is. Dangling links are followed to where
+// they would create their target.
+func evalExisting(path string) (string, error) {
+	rest := ""
+	current := path
+	for links := 0; ; {
+		resolved, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		info, err := os.Lstat(current)
+		if err == nil && info.Mode()&os.ModeSymlink != 0 {
+			links++
+			if links > 255 {
+				return "", errors.New("Too many links in '" + path + "'")
+			}
+			link, err := os.Readlink(current)
+			if err != nil {
+				return "", err
+			}
+			if !filepath.IsAbs(link) {
+				link = filepath.Join(filepath.Dir(current), link)
+			}
+			current = link
+			continue
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return path, nil
+		}
+		rest = filepath.Join(filepath.Base(current), rest)
+		current = parent
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sizes
+
+import (
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8web"
+	"github.com/saichler/l8utils/go/utils/web"
+)
+
+const (
+	ServiceName = "Sizes"
+	ServiceType = "SizeService"
+	ServiceArea = byte(0)
+)
+
+type SizeService struct {
+	sla *ifs.ServiceLevelAgreement
+}
+
+func Activate(vnic ifs.IVNic) {
+	sla := ifs.NewServiceLevelAgreement(&SizeService{}, ServiceName, ServiceArea, false, nil)
+	ws := web.New(ServiceName, ServiceArea, 0)
+	ws.AddEndpoint(&files.SizeRequest{}, ifs.POST, &files.SizeResult{})
+	sla.SetWebService(ws)
+	vnic.Resources().Services().Activate(sla, vnic)
+}
+
+func (this *SizeService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	vnic.Resources().Registry().Register(&files.SizeRequest{})
+	vnic.Resources().Registry().Register(&files.SizeResult{})
+	vnic.Resources().Registry().Register(&files.DirSize{})
+	vnic.Resources().Registry().Register(&l8web.L8Empty{})
+	this.sla = sla
+	return nil
+}
+
+func (this *SizeService) DeActivate() error {
+	return nil
+}
+
+// Post starts calculating the sizes of the paths when there is no id,
+// otherwise it returns the result of the calculation, cancelling it if
+// requested. Unless async, the result is returned once it is complete.
+func (this *SizeService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	req, ok := pb.Element().(*files.SizeRequest)
+	if !ok {
+		return object.New(nil, &l8web.L8Empty{})
+	}
+	var calc *Calculation
+	if req.Id == "" {
+		if len(req.Paths) == 0 {
+			return object.NewError("No paths to calculate")
+		}
+		calc = Start(req.Paths)
+	} else {
+		calc = Get(req.Id)
+		if calc == nil {
+			return object.NewError("Size calculation '" + req.Id + "' does not exist")
+		}
+		if req.Cancel {
+			Cancel(req.Id)
+		}
+	}
+	if !req.Async {
+		calc.Wait()
+	}
+	return object.New(nil, calc.Result())
+}
+
+func (this *SizeService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *SizeService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *SizeService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+
+func (this *SizeService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *SizeService) GetCopy(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *SizeService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+func (this *SizeService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *SizeService) WebService() ifs.IWebService {
+	return this.sla.WebService()
+}
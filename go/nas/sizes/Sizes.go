@@ -0,0 +1,319 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sizes calculates the recursive size, file count and directory
+// count of directories. Subdirectories are walked concurrently by a bounded
+// number of workers and files with several hard links are counted once.
+// The names in every directory are cached by its modification time, so a
+// repeated calculation only lists the directories that changed. The files
+// are stat-ed every time, since writing to a file does not change the
+// modification time of its directory.
+package sizes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/registry"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Workers is the number of directories read concurrently by all the calculations
+	Workers = 8
+	// MaxCacheEntries bounds the number of cached directories
+	MaxCacheEntries = 100000
+	// keep is how long a finished calculation is kept for its result to be fetched
+	keep = 10 * time.Minute
+)
+
+// Calculation is a running or finished size calculation of one or more paths.
+type Calculation struct {
+	id        string
+	mtx       *sync.Mutex
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+	sizes     []*files.DirSize
+	cancelled bool
+	ended     time.Time
+}
+
+// inode identifies a file with several hard links.
+type inode struct {
+	dev uint64
+	ino uint64
+}
+
+type link struct {
+	id   inode
+	size int64
+}
+
+// entry is the cached listing of a directory.
+type entry struct {
+	modified int64
+	files    []string
+	dirs     []string
+}
+
+var calculations = registry.New(keep)
+
+var cacheMtx = &sync.RWMutex{}
+var cache = make(map[string]*entry)
+
+// slots bounds the directories walked concurrently by all the calculations
+var slots = make(chan struct{}, Workers)
+
+// Start calculates the sizes of the virtual paths in the background.
+func Start(paths []string) *Calculation {
+	ctx, cancel := context.WithCancel(context.Background())
+	calc := &Calculation{id: registry.NewId(), mtx: &sync.Mutex{}, ctx: ctx, cancel: cancel,
+		done: make(chan struct{}), sizes: make([]*files.DirSize, len(paths))}
+	for i, path := range paths {
+		calc.sizes[i] = &files.DirSize{Path: shares.Clean(path)}
+	}
+	calculations.Add(calc.id, calc)
+
+	go func() {
+		wg := &sync.WaitGroup{}
+		for i, path := range paths {
+			wg.Add(1)
+			go func(i int, path string) {
+				defer wg.Done()
+				calc.calculate(i, shares.Clean(path))
+			}(i, path)
+		}
+		wg.Wait()
+		calc.mtx.Lock()
+		calc.ended = time.Now()
+		calc.mtx.Unlock()
+		calc.cancel()
+		close(calc.done)
+	}()
+	return calc
+}
+
+// calculate sets the totals of the i-th path.
+func (this *Calculation) calculate(i int, path string) {
+	result := &files.DirSize{Path: path}
+	defer func() {
+		this.mtx.Lock()
+		this.sizes[i] = result
+		this.mtx.Unlock()
+	}()
+	if shares.IsRoot(path) {
+		result.Error = "Path '/' is not inside a share"
+		return
+	}
+	realPath, err := shares.Resolve(path)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	info, err := os.Lstat(realPath)
+	if err != nil {
+		result.Error = "File not found"
+		return
+	}
+	if !info.IsDir() {
+		result.Size = info.Size()
+		result.Files = 1
+		return
+	}
+	w := &walker{ctx: this.ctx, seen: make(map[inode]bool), mtx: &sync.Mutex{}}
+	w.dir(realPath, result)
+	if this.ctx.Err() != nil {
+		result.Error = "Cancelled"
+	}
+}
+
+// walker sums the totals of a tree, deduplicating the hard links.
+type walker struct {
+	ctx  context.Context
+	seen map[inode]bool
+	mtx  *sync.Mutex
+}
+
+// dir adds the totals of the directory and its subdirectories to result.
+// Subdirectories are walked in new goroutines while there are free workers
+// and in the current one otherwise.
+func (this *walker) dir(path string, result *files.DirSize) {
+	if this.ctx.Err() != nil {
+		return
+	}
+	e, err := read(path)
+	if err != nil {
+		return
+	}
+	totals := &files.DirSize{}
+	links := stat(path, e.files, totals)
+	this.mtx.Lock()
+	for _, l := range links {
+		if !this.seen[l.id] {
+			this.seen[l.id] = true
+			totals.Size += l.size
+			totals.Files++
+		}
+	}
+	this.mtx.Unlock()
+
+	wg := &sync.WaitGroup{}
+	subs := make([]*files.DirSize, len(e.dirs))
+	for i, name := range e.dirs {
+		sub := filepath.Join(path, name)
+		if trash.IsTrash(sub) {
+			continue
+		}
+		totals.Directories++
+		subs[i] = &files.DirSize{}
+		select {
+		case slots <- struct{}{}:
+			wg.Add(1)
+			go func(sub string, subTotals *files.DirSize) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				this.dir(sub, subTotals)
+			}(sub, subs[i])
+		default:
+			this.dir(sub, subs[i])
+		}
+	}
+	wg.Wait()
+	for _, sub := range subs {
+		if sub != nil {
+			totals.Size += sub.Size
+			totals.Files += sub.Files
+			totals.Directories += sub.Directories
+		}
+	}
+	result.Size += totals.Size
+	result.Files += totals.Files
+	result.Directories += totals.Directories
+}
+
+// read returns the names in the directory, from the cache if the directory
+// was not modified since it was cached.
+func read(path string) (*entry, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	modified := info.ModTime().UnixNano()
+	cacheMtx.RLock()
+	e, ok := cache[path]
+	cacheMtx.RUnlock()
+	if ok && e.modified == modified {
+		return e, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	e = &entry{modified: modified, files: make([]string, 0), dirs: make([]string, 0)}
+	for _, dirEntry := range entries {
+		if dirEntry.IsDir() {
+			e.dirs = append(e.dirs, dirEntry.Name())
+		} else {
+			e.files = append(e.files, dirEntry.Name())
+		}
+	}
+
+	cacheMtx.Lock()
+	if len(cache) >= MaxCacheEntries {
+		cache = make(map[string]*entry)
+	}
+	cache[path] = e
+	cacheMtx.Unlock()
+	return e, nil
+}
+
+// stat adds the current size of the files in the directory to totals and
+// returns the files with several hard links, which are counted once per tree.
+func stat(path string, names []string, totals *files.DirSize) []link {
+	links := make([]link, 0)
+	for _, name := range names {
+		info, err := os.Lstat(filepath.Join(path, name))
+		if err != nil || info.IsDir() {
+			continue
+		}
+		st, ok := info.Sys().(*syscall.Stat_t)
+		if ok && st.Nlink > 1 && info.Mode().IsRegular() {
+			links = append(links, link{id: inode{dev: uint64(st.Dev), ino: uint64(st.Ino)}, size: info.Size()})
+			continue
+		}
+		totals.Size += info.Size()
+		totals.Files++
+	}
+	return links
+}
+
+// Get returns the calculation by its id, or nil if there is no such calculation.
+func Get(id string) *Calculation {
+	calc, _ := calculations.Get(id).(*Calculation)
+	return calc
+}
+
+// Cancel stops the calculation, returns false if there is no such calculation.
+func Cancel(id string) bool {
+	return calculations.Cancel(id)
+}
+
+// Cancel stops the calculation, it is marked cancelled unless it already ended.
+func (this *Calculation) Cancel() {
+	this.mtx.Lock()
+	if this.ended.IsZero() {
+		this.cancelled = true
+	}
+	this.mtx.Unlock()
+	this.cancel()
+}
+
+// Ended returns when the calculation finished, the zero time while it runs.
+func (this *Calculation) Ended() time.Time {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	return this.ended
+}
+
+func (this *Calculation) Id() string {
+	return this.id
+}
+
+// Result returns the sizes calculated so far, the paths that are still
+// being calculated have zero totals.
+func (this *Calculation) Result() *files.SizeResult {
+	this.mtx.Lock()
+	defer this.mtx.Unlock()
+	result := &files.SizeResult{Id: this.id, Done: !this.ended.IsZero(), Cancelled: this.cancelled,
+		Sizes: make([]*files.DirSize, len(this.sizes))}
+	for i, size := range this.sizes {
+		result.Sizes[i] = proto.Clone(size).(*files.DirSize)
+	}
+	return result
+}
+
+// Wait blocks until the calculation is finished.
+func (this *Calculation) Wait() {
+	<-this.done
+}
@@ -0,0 +1,214 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package thumbnails
+
+import (
+	"bufio"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"io"
+)
+
+// scale shrinks the image to fit in a size by size square, keeping its
+// aspect ratio. Every target pixel is the average of the source pixels it
+// covers, which gives smooth thumbnails without an intermediate copy of the
+// source. Images that already fit are only converted.
+func scale(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	width, height := sw, sh
+	if sw > size || sh > size {
+		if sw >= sh {
+			width, height = size, sh*size/sw
+		} else {
+			width, height = sw*size/sh, size
+		}
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	pixel := pixelReader(src)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := span(y, sh, height)
+		for x := 0; x < width; x++ {
+			x0, x1 := span(x, sw, width)
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := pixel(b.Min.X+sx, b.Min.Y+sy)
+					r, g, bl, a, n = r+uint64(pr), g+uint64(pg), bl+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			off := dst.PixOffset(x, y)
+			dst.Pix[off] = uint8(r / n)
+			dst.Pix[off+1] = uint8(g / n)
+			dst.Pix[off+2] = uint8(bl / n)
+			dst.Pix[off+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// span returns the source range covered by the i-th of n target pixels.
+func span(i, source, n int) (int, int) {
+	start := i * source / n
+	end := (i + 1) * source / n
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
+
+// pixelReader returns a function reading the 8 bit premultiplied color of a
+// pixel, with fast paths for the common decoded image types.
+func pixelReader(src image.Image) func(x, y int) (uint32, uint32, uint32, uint32) {
+	switch img := src.(type) {
+	case *image.YCbCr:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			yi, ci := img.YOffset(x, y), img.COffset(x, y)
+			r, g, b := color.YCbCrToRGB(img.Y[yi], img.Cb[ci], img.Cr[ci])
+			return uint32(r), uint32(g), uint32(b), 255
+		}
+	case *image.RGBA:
+		return func(x, y int) (uint32, uint32, uint32, uint32) {
+			off := img.PixOffset(x, y)
+			return uint32(img.Pix[off]), uint32(img.Pix[off+1]), uint32(img.Pix[off+2]), uint32(img.Pix[off+3])
+		}
+	}
+	return func(x, y int) (uint32, uint32, uint32, uint32) {
+		r, g, b, a := src.At(x, y).RGBA()
+		return r >> 8, g >> 8, b >> 8, a >> 8
+	}
+}
+
+// orient applies the EXIF orientation, 1 to 8, so the image is upright.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
+
+// exifOrientation returns the orientation tag of a JPEG image, 1 when it has
+// none. Only the segments before the image data are read.
+func exifOrientation(r io.Reader) int {
+	br := bufio.NewReader(io.LimitReader(r, 1<<20))
+	soi := make([]byte, 2)
+	if _, err := io.ReadFull(br, soi); err != nil || soi[0] != 0xff || soi[1] != 0xd8 {
+		return 1
+	}
+	for {
+		b, err := br.ReadByte()
+		if err != nil || b != 0xff {
+			return 1
+		}
+		marker, err := br.ReadByte()
+		for err == nil && marker == 0xff {
+			marker, err = br.ReadByte()
+		}
+		// Start of scan or end of image, there is no orientation
+		if err != nil || marker == 0xda || marker == 0xd9 {
+			return 1
+		}
+		length := make([]byte, 2)
+		if _, err = io.ReadFull(br, length); err != nil {
+			return 1
+		}
+		n := int(binary.BigEndian.Uint16(length)) - 2
+		if n < 0 {
+			return 1
+		}
+		data := make([]byte, n)
+		if _, err = io.ReadFull(br, data); err != nil {
+			return 1
+		}
+		if marker == 0xe1 && len(data) > 6 && string(data[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(data[6:])
+		}
+	}
+}
+
+// tiffOrientation reads the orientation tag from the first IFD of the TIFF
+// structure of the EXIF segment.
+func tiffOrientation(data []byte) int {
+	if len(data) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(data[2:4]) != 42 {
+		return 1
+	}
+	offset := int(order.Uint32(data[4:8]))
+	if offset < 8 || offset+2 > len(data) {
+		return 1
+	}
+	count := int(order.Uint16(data[offset : offset+2]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(data) {
+			return 1
+		}
+		if order.Uint16(data[entry:entry+2]) == 0x0112 {
+			value := int(order.Uint16(data[entry+8 : entry+10]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+	return 1
+}
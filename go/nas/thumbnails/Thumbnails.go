@@ -0,0 +1,330 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package thumbnails generates the thumbnails of JPEG, PNG, GIF and WebP
+// images, upright according to their EXIF orientation. Thumbnails are cached
+// on disk, keyed by the path, the modification time and the size of the image,
+// and the thumbnails of the listed images are generated in the background
+// ahead of their first request. The requested sizes are snapped to a few
+// fixed sizes, so the cache holds a bounded number of thumbnails per image.
+package thumbnails
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8types/go/ifs"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// DefaultSize is the size of the thumbnails generated in the background
+	DefaultSize = 256
+	MaxSize     = 1024
+	// MaxPixels rejects the images too large to be decoded safely
+	MaxPixels = 64 << 20
+	// queueLength bounds the images waiting for a background thumbnail
+	queueLength = 1000
+	jpegQuality = 80
+)
+
+var mtx = &sync.Mutex{}
+var cfg = config.Default().Thumbnails
+var queue chan string
+var queued = make(map[string]bool)
+var inflight = make(map[string]chan struct{})
+var startOnce = &sync.Once{}
+
+// sizes are the sizes the thumbnails are generated in
+var sizes = []int{64, 128, DefaultSize, 512, MaxSize}
+
+// slots bounds the thumbnails generated concurrently, in the background and
+// on demand
+var slots = make(chan struct{}, config.Default().Thumbnails.Workers)
+
+// Configure sets the cache directory and starts the background workers and
+// the purge of the unused thumbnails.
+func Configure(thumbnailConfig *config.ThumbnailConfig) error {
+	err := os.MkdirAll(thumbnailConfig.Dir, 0700)
+	if err != nil {
+		return err
+	}
+	mtx.Lock()
+	cfg = thumbnailConfig
+	mtx.Unlock()
+	if !thumbnailConfig.Enabled {
+		return nil
+	}
+	startOnce.Do(func() {
+		workers := thumbnailConfig.Workers
+		if workers <= 0 {
+			workers = 1
+		}
+		mtx.Lock()
+		queue = make(chan string, queueLength)
+		slots = make(chan struct{}, workers)
+		mtx.Unlock()
+		for i := 0; i < workers; i++ {
+			go work()
+		}
+		go purgeLoop()
+	})
+	return nil
+}
+
+// IsImage returns true if a thumbnail can be generated for the file name.
+func IsImage(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
+		return true
+	}
+	return false
+}
+
+// Prefetch queues the images for their default thumbnail to be generated in
+// the background. Images are dropped when the queue is full, their thumbnail
+// is then generated on its first request.
+func Prefetch(realPaths []string) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	if queue == nil || !cfg.Enabled {
+		return
+	}
+	for _, realPath := range realPaths {
+		if queued[realPath] || !IsImage(realPath) {
+			continue
+		}
+		select {
+		case queue <- realPath:
+			queued[realPath] = true
+		default:
+			return
+		}
+	}
+}
+
+func work() {
+	for realPath := range queue {
+		Get(realPath, DefaultSize)
+		mtx.Lock()
+		delete(queued, realPath)
+		mtx.Unlock()
+	}
+}
+
+// snap returns the smallest of the sizes the size fits in.
+func snap(size int) int {
+	for _, s := range sizes {
+		if size <= s {
+			return s
+		}
+	}
+	return MaxSize
+}
+
+// Get returns the path of the cached thumbnail of the image, fitting in a
+// square of the size snapped to the sizes, generating it if needed, with its
+// content type.
+func Get(realPath string, size int) (string, string, error) {
+	size = snap(size)
+	if !IsImage(realPath) {
+		return "", "", errors.New("File '" + filepath.Base(realPath) + "' is not a supported image")
+	}
+	info, err := os.Stat(realPath)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", "", errors.New("File '" + filepath.Base(realPath) + "' does not exist")
+	}
+	cachePath, contentType := cached(realPath, info, size)
+
+	for {
+		if _, err = os.Stat(cachePath); err == nil {
+			// Used thumbnails are kept by the purge
+			now := time.Now()
+			os.Chtimes(cachePath, now, now)
+			return cachePath, contentType, nil
+		}
+		mtx.Lock()
+		wait, busy := inflight[cachePath]
+		if !busy {
+			inflight[cachePath] = make(chan struct{})
+		}
+		mtx.Unlock()
+		if !busy {
+			break
+		}
+		<-wait
+	}
+	defer func() {
+		mtx.Lock()
+		close(inflight[cachePath])
+		delete(inflight, cachePath)
+		mtx.Unlock()
+	}()
+	mtx.Lock()
+	sem := slots
+	mtx.Unlock()
+	sem <- struct{}{}
+	err = generate(realPath, cachePath, size)
+	<-sem
+	if err != nil {
+		return "", "", err
+	}
+	return cachePath, contentType, nil
+}
+
+// cached returns the cache path of the thumbnail and its content type, JPEG
+// for JPEG images and PNG for the others, which may be transparent.
+func cached(realPath string, info fs.FileInfo, size int) (string, string) {
+	sum := sha256.Sum256([]byte(realPath + "\x00" + strconv.FormatInt(info.ModTime().UnixNano(), 10) + "\x00" +
+		strconv.FormatInt(info.Size(), 10) + "\x00" + strconv.Itoa(size)))
+	key := hex.EncodeToString(sum[:])
+	ext, contentType := ".png", "image/png"
+	if isJpeg(realPath) {
+		ext, contentType = ".jpg", "image/jpeg"
+	}
+	mtx.Lock()
+	dir := cfg.Dir
+	mtx.Unlock()
+	return filepath.Join(dir, key[:2], key+ext), contentType
+}
+
+func isJpeg(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".jpg" || ext == ".jpeg"
+}
+
+// generate decodes the image, scales and orients it and writes the
+// thumbnail to a temporary file renamed into the cache once complete.
+func generate(realPath, cachePath string, size int) error {
+	file, err := os.Open(realPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	imageConfig, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return errors.New("File '" + filepath.Base(realPath) + "' is not a supported image")
+	}
+	if int64(imageConfig.Width)*int64(imageConfig.Height) > MaxPixels {
+		return errors.New("Image '" + filepath.Base(realPath) + "' is too large")
+	}
+	orientation := 1
+	if isJpeg(realPath) {
+		if _, err = file.Seek(0, 0); err != nil {
+			return err
+		}
+		orientation = exifOrientation(file)
+	}
+	if _, err = file.Seek(0, 0); err != nil {
+		return err
+	}
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return errors.New("File '" + filepath.Base(realPath) + "' is not a supported image")
+	}
+	thumbnail := orient(scale(img, size), orientation)
+
+	err = os.MkdirAll(filepath.Dir(cachePath), 0700)
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(cachePath), ".thumbnail-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if strings.HasSuffix(cachePath, ".jpg") {
+		err = jpeg.Encode(temp, thumbnail, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(temp, thumbnail)
+	}
+	closeErr := temp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), cachePath)
+}
+
+// purgeLoop removes the thumbnails that were not used for longer than the
+// configured maximum age.
+func purgeLoop() {
+	for {
+		mtx.Lock()
+		dir, maxAge := cfg.Dir, time.Duration(cfg.MaxAge)*time.Second
+		mtx.Unlock()
+		if maxAge > 0 {
+			filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return nil
+				}
+				info, err := d.Info()
+				if err == nil && time.Since(info.ModTime()) > maxAge {
+					os.Remove(path)
+				}
+				return nil
+			})
+		}
+		time.Sleep(time.Hour)
+	}
+}
+
+// Handler serves the thumbnail of the image in the "path" query parameter,
+// "size" is the size of the square it fits in, 256 by default, snapped up to
+// 64, 128, 256, 512 or 1024.
+func Handler(w http.ResponseWriter, r *http.Request, resources ifs.IResources) {
+	realPath, err := shares.Resolve(r.URL.Query().Get("path"))
+	if err != nil || shares.IsRoot(r.URL.Query().Get("path")) {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil || size <= 0 {
+		size = DefaultSize
+	}
+	cachePath, contentType, err := Get(realPath, size)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	file, err := os.Open(cachePath)
+	if err != nil {
+		http.Error(w, "Thumbnail not found", http.StatusNotFound)
+		return
+	}
+	defer file.Close()
+	// The cache key changes with the image, so it is a strong validator
+	w.Header().Set("ETag", "\""+strings.TrimSuffix(filepath.Base(cachePath), filepath.Ext(cachePath))+"\"")
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	http.ServeContent(w, r, "", time.Time{}, file)
+}
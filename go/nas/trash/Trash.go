@@ -0,0 +1,260 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package trash keeps deleted files in a recycle bin per share, from which
+// they can be restored until they are purged. Each share has a hidden trash
+// directory under its root, so deleting is a rename on the same file system.
+// The content of an item is kept in "files/<id>" and its metadata, the
+// original path, the deleting user and the time, in "info/<id>.json".
+package trash
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Dir is the name of the trash directory under the root of every share.
+const Dir = ".trash"
+
+// The trash is only reached through the trash actions
+func init() {
+	shares.Reserve(Dir)
+}
+
+var mtx = &sync.Mutex{}
+var cfg = &config.TrashConfig{}
+var purgeOnce = &sync.Once{}
+
+// Configure sets the trash configuration and starts the automatic purge.
+func Configure(trashConfig *config.TrashConfig) {
+	mtx.Lock()
+	cfg = trashConfig
+	mtx.Unlock()
+	if trashConfig.Enabled {
+		purgeOnce.Do(func() {
+			go purge()
+		})
+	}
+}
+
+// Enabled returns true when deleted files should be moved to the trash.
+func Enabled() bool {
+	mtx.Lock()
+	defer mtx.Unlock()
+	return cfg.Enabled
+}
+
+// IsTrash returns true if the real path is inside the trash of its share.
+func IsTrash(realPath string) bool {
+	for _, share := range shares.List() {
+		if shares.IsWithin(realPath, filepath.Join(share.RealRoot(), Dir)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add records the entry at the virtual path as deleted by user. It returns
+// the new item and the real path the entry should be moved to.
+func Add(virtualPath string, isDirectory bool, size int64, user string) (*files.TrashItem, string, error) {
+	share, rel, err := shares.Split(virtualPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if rel == "" {
+		return nil, "", errors.New("Cannot move share root '" + shares.Clean(virtualPath) + "' to the trash")
+	}
+	err = os.MkdirAll(filesDir(share), 0700)
+	if err == nil {
+		err = os.MkdirAll(infoDir(share), 0700)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	item := &files.TrashItem{
+		Id:          newId(),
+		Share:       share.Name,
+		Path:        shares.Clean(virtualPath),
+		Name:        filepath.Base(rel),
+		IsDirectory: isDirectory,
+		Size:        size,
+		User:        user,
+		Deleted:     time.Now().Unix(),
+	}
+	data, err := protojson.Marshal(item)
+	if err != nil {
+		return nil, "", err
+	}
+	err = os.WriteFile(infoFile(share, item.Id), data, 0600)
+	if err != nil {
+		return nil, "", err
+	}
+	return item, Path(share, item.Id), nil
+}
+
+// Path returns the real path of the content of the item in the trash.
+func Path(share *shares.Share, id string) string {
+	return filepath.Join(filesDir(share), id)
+}
+
+// Find returns the item with the id that the user deleted and the share it
+// belongs to. The items of other users are not found.
+func Find(id, user string) (*shares.Share, *files.TrashItem, error) {
+	if !validId(id) {
+		return nil, nil, errors.New("Invalid trash id '" + id + "'")
+	}
+	for _, share := range shares.List() {
+		data, err := os.ReadFile(infoFile(share, id))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		item := &files.TrashItem{}
+		err = protojson.Unmarshal(data, item)
+		if err != nil {
+			return nil, nil, err
+		}
+		if item.User != user {
+			break
+		}
+		return share, item, nil
+	}
+	return nil, nil, errors.New("Trash item '" + id + "' does not exist")
+}
+
+// List returns the items in the trash of the share, latest deleted first.
+func List(share *shares.Share) ([]*files.TrashItem, error) {
+	entries, err := os.ReadDir(infoDir(share))
+	if os.IsNotExist(err) {
+		return []*files.TrashItem{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*files.TrashItem, 0, len(entries))
+	for _, entry := range entries {
+		id := strings.TrimSuffix(entry.Name(), ".json")
+		if !validId(id) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(infoDir(share), entry.Name()))
+		if err != nil {
+			continue
+		}
+		item := &files.TrashItem{}
+		if protojson.Unmarshal(data, item) == nil {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Deleted > items[j].Deleted
+	})
+	return items, nil
+}
+
+// Forget removes the metadata of the item, once its content was restored,
+// purged or could not be moved to the trash.
+func Forget(share *shares.Share, id string) error {
+	err := os.Remove(infoFile(share, id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// purge removes the items older than the maximum age, and then the oldest
+// items of every share whose trash exceeds the maximum size.
+func purge() {
+	for {
+		time.Sleep(10 * time.Minute)
+		mtx.Lock()
+		maxAge, maxSize := cfg.MaxAge, cfg.MaxSize
+		mtx.Unlock()
+		for _, share := range shares.List() {
+			purgeShare(share, maxAge, maxSize)
+		}
+	}
+}
+
+func purgeShare(share *shares.Share, maxAge, maxSize int64) {
+	items, err := List(share)
+	if err != nil {
+		return
+	}
+	deadline := time.Now().Unix() - maxAge
+	var total int64
+	for _, item := range items {
+		if maxAge > 0 && item.Deleted < deadline {
+			remove(share, item.Id)
+			continue
+		}
+		total += item.Size
+		if maxSize > 0 && total > maxSize {
+			remove(share, item.Id)
+		}
+	}
+}
+
+func remove(share *shares.Share, id string) {
+	if os.RemoveAll(Path(share, id)) == nil {
+		Forget(share, id)
+	}
+}
+
+func filesDir(share *shares.Share) string {
+	return filepath.Join(share.RealRoot(), Dir, "files")
+}
+
+func infoDir(share *shares.Share) string {
+	return filepath.Join(share.RealRoot(), Dir, "info")
+}
+
+func infoFile(share *shares.Share, id string) string {
+	return filepath.Join(infoDir(share), id+".json")
+}
+
+// newId returns a unique id that sorts by the deletion time.
+func newId() string {
+	buff := make([]byte, 4)
+	rand.Read(buff)
+	return strconv.FormatInt(time.Now().UnixNano(), 16) + hex.EncodeToString(buff)
+}
+
+func validId(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, c := range id {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package usage analyzes what takes the space under a directory, in the
+// spirit of ncdu. A single walk builds a tree of the largest entries down
+// to the requested depth and breaks the files down by extension and by age.
+package usage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	files2 "github.com/saichler/l8nasfile/go/nas/files"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+const (
+	DefaultDepth = 2
+	MaxDepth     = 10
+	DefaultTop   = 10
+	MaxTop       = 100
+	// MaxExtensions is the number of extensions in the breakdown, the rest
+	// are summed as "other"
+	MaxExtensions = 50
+)
+
+// ageBucket groups the files modified within the last "within" duration.
+type ageBucket struct {
+	name   string
+	within time.Duration
+}
+
+var ageBuckets = []ageBucket{
+	{"last day", 24 * time.Hour},
+	{"last week", 7 * 24 * time.Hour},
+	{"last month", 30 * 24 * time.Hour},
+	{"last year", 365 * 24 * time.Hour},
+	{"older", 0},
+}
+
+// inode identifies a file with several hard links, which is counted once.
+type inode struct {
+	dev uint64
+	ino uint64
+}
+
+type analyzer struct {
+	now        time.Time
+	depth      int
+	top        int
+	seen       map[inode]bool
+	extensions map[string]*files.UsageGroup
+	ages       []*files.UsageGroup
+}
+
+// Analyze returns the usage report of the virtual path.
+func Analyze(req *files.UsageRequest) (*files.UsageReport, error) {
+	if shares.IsRoot(req.Path) {
+		return nil, errors.New("Path '/' is not inside a share")
+	}
+	realPath, err := shares.Resolve(req.Path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Lstat(realPath)
+	if err != nil {
+		return nil, errors.New("Path '" + shares.Clean(req.Path) + "' does not exist")
+	}
+	this := &analyzer{now: time.Now(), depth: bounded(req.Depth, DefaultDepth, MaxDepth),
+		top: bounded(req.Top, DefaultTop, MaxTop), seen: make(map[inode]bool),
+		extensions: make(map[string]*files.UsageGroup), ages: make([]*files.UsageGroup, len(ageBuckets))}
+	for i, bucket := range ageBuckets {
+		this.ages[i] = &files.UsageGroup{Name: bucket.name}
+	}
+
+	clean := shares.Clean(req.Path)
+	report := &files.UsageReport{Root: this.node(realPath, filepath.Dir(clean), filepath.Base(clean), info, 0)}
+	report.TotalSpace, report.FreeSpace, _ = files2.Space(realPath)
+	report.Ages = this.ages
+	report.Extensions = this.breakdown()
+	return report, nil
+}
+
+func bounded(value int32, def, max int) int {
+	if value <= 0 {
+		return def
+	}
+	if int(value) > max {
+		return max
+	}
+	return int(value)
+}
+
+// node returns the usage of the entry, with its largest children while it is
+// above the requested depth.
+func (this *analyzer) node(realPath, virtualDir, name string, info os.FileInfo, level int) *files.UsageNode {
+	n := &files.UsageNode{Name: name, Path: virtualDir, IsDirectory: info.IsDir(),
+		Modified: info.ModTime().Unix()}
+	if !info.IsDir() {
+		if this.claim(info) {
+			n.Size = info.Size()
+			n.Files = 1
+			this.account(info)
+		}
+		return n
+	}
+
+	entries, err := os.ReadDir(realPath)
+	if err != nil {
+		return n
+	}
+	virtualPath := shares.Clean(virtualDir + "/" + name)
+	children := make([]*files.UsageNode, 0, len(entries))
+	for _, entry := range entries {
+		childPath := filepath.Join(realPath, entry.Name())
+		if entry.IsDir() && trash.IsTrash(childPath) {
+			continue
+		}
+		childInfo, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		child := this.node(childPath, virtualPath, entry.Name(), childInfo, level+1)
+		n.Size += child.Size
+		n.Files += child.Files
+		n.Directories += child.Directories
+		if child.IsDirectory {
+			n.Directories++
+		}
+		if level < this.depth {
+			children = append(children, child)
+		}
+	}
+	if level >= this.depth {
+		return n
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Size > children[j].Size
+	})
+	if len(children) > this.top {
+		for _, other := range children[this.top:] {
+			n.Others++
+			n.OthersSize += other.Size
+		}
+		children = children[:this.top]
+	}
+	n.Children = children
+	return n
+}
+
+// claim returns false for a hard linked file that was already counted.
+func (this *analyzer) claim(info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || stat.Nlink < 2 || !info.Mode().IsRegular() {
+		return true
+	}
+	id := inode{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}
+	if this.seen[id] {
+		return false
+	}
+	this.seen[id] = true
+	return true
+}
+
+// account adds the file to its extension and age groups.
+func (this *analyzer) account(info os.FileInfo) {
+	ext := strings.ToLower(filepath.Ext(info.Name()))
+	if ext == "" || ext == info.Name() {
+		ext = "(none)"
+	}
+	group, ok := this.extensions[ext]
+	if !ok {
+		group = &files.UsageGroup{Name: ext}
+		this.extensions[ext] = group
+	}
+	group.Size += info.Size()
+	group.Files++
+
+	age := this.now.Sub(info.ModTime())
+	for i, bucket := range ageBuckets {
+		if bucket.within == 0 || age < bucket.within {
+			this.ages[i].Size += info.Size()
+			this.ages[i].Files++
+			break
+		}
+	}
+}
+
+// breakdown returns the extensions from the largest, summing the smallest
+// ones as "other".
+func (this *analyzer) breakdown() []*files.UsageGroup {
+	list := make([]*files.UsageGroup, 0, len(this.extensions))
+	for _, group := range this.extensions {
+		list = append(list, group)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Size == list[j].Size {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Size > list[j].Size
+	})
+	if len(list) <= MaxExtensions {
+		return list
+	}
+	other := &files.UsageGroup{Name: "other"}
+	for _, group := range list[MaxExtensions:] {
+		other.Size += group.Size
+		other.Files += group.Files
+	}
+	return append(list[:MaxExtensions], other)
+}
@@ -0,0 +1,95 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package usage
+
+import (
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8web"
+	"github.com/saichler/l8utils/go/utils/web"
+)
+
+const (
+	ServiceName = "Usage"
+	ServiceType = "UsageService"
+	ServiceArea = byte(0)
+)
+
+type UsageService struct {
+	sla *ifs.ServiceLevelAgreement
+}
+
+func Activate(vnic ifs.IVNic) {
+	sla := ifs.NewServiceLevelAgreement(&UsageService{}, ServiceName, ServiceArea, false, nil)
+	ws := web.New(ServiceName, ServiceArea, 0)
+	ws.AddEndpoint(&files.UsageRequest{}, ifs.POST, &files.UsageReport{})
+	sla.SetWebService(ws)
+	vnic.Resources().Services().Activate(sla, vnic)
+}
+
+func (this *UsageService) Activate(sla *ifs.ServiceLevelAgreement, vnic ifs.IVNic) error {
+	vnic.Resources().Registry().Register(&files.UsageRequest{})
+	vnic.Resources().Registry().Register(&files.UsageReport{})
+	vnic.Resources().Registry().Register(&files.UsageNode{})
+	vnic.Resources().Registry().Register(&l8web.L8Empty{})
+	this.sla = sla
+	return nil
+}
+
+func (this *UsageService) DeActivate() error {
+	return nil
+}
+
+// Post returns the usage report of the requested path.
+func (this *UsageService) Post(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	req, ok := pb.Element().(*files.UsageRequest)
+	if !ok {
+		return object.New(nil, &l8web.L8Empty{})
+	}
+	report, err := Analyze(req)
+	if err != nil {
+		return object.NewError(err.Error())
+	}
+	return object.New(nil, report)
+}
+
+func (this *UsageService) Put(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *UsageService) Patch(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *UsageService) Delete(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+
+func (this *UsageService) Get(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *UsageService) GetCopy(pb ifs.IElements, vnic ifs.IVNic) ifs.IElements {
+	return nil
+}
+func (this *UsageService) Failed(pb ifs.IElements, vnic ifs.IVNic, msg *ifs.Message) ifs.IElements {
+	return nil
+}
+func (this *UsageService) TransactionConfig() ifs.ITransactionConfig {
+	return nil
+}
+
+func (this *UsageService) WebService() ifs.IWebService {
+	return this.sla.WebService()
+}
@@ -91,38 +91,17 @@ class FileManagerAPI {
     }
 
     async deleteFiles(paths) {
-        // Process each path individually
-        const results = [];
-        const errors = [];
-
-        for (const path of paths) {
+        // Delete all the paths in a single batch action
+        const sources = paths.map(path => {
             const pathParts = path.split('/');
             const fileName = pathParts.pop();
-            const dirPath = pathParts.join('/');
-
-            try {
-                const result = await this.request('/files/0/Actions', {
-                    method: 'POST',
-                    body: JSON.stringify({
-                        action: 'delete',
-                        source: {
-                            path: dirPath,
-                            name: fileName
-                        }
-                    })
-                });
-                results.push(result);
-            } catch (error) {
-                errors.push({ path, error: error.message });
-            }
-        }
-
-        if (errors.length > 0) {
-            const errorMsg = errors.map(e => `${e.path}: ${e.error}`).join(', ');
-            throw new Error(errorMsg);
-        }
+            return { path: pathParts.join('/'), name: fileName };
+        });
 
-        return results;
+        return this.runJob({
+            action: 'delete',
+            sources: sources
+        });
     }
 
     async renameFile(oldPath, newName) {
@@ -130,7 +109,7 @@ class FileManagerAPI {
         const oldName = pathParts.pop();
         const dirPath = pathParts.join('/');
 
-        return this.request('/files/0/Actions', {
+        return this.request('/files/actions', {
             method: 'POST',
             body: JSON.stringify({
                 action: 'rename',
@@ -146,82 +125,56 @@ class FileManagerAPI {
         });
     }
 
-    async copyFiles(sources, destination) {
-        const results = [];
-        const errors = [];
-
-        for (const sourcePath of sources) {
-            const pathParts = sourcePath.split('/');
-            const fileName = pathParts.pop();
-            const sourceDirPath = pathParts.join('/');
-
-            try {
-                const result = await this.request('/files/0/Actions', {
-                    method: 'POST',
-                    body: JSON.stringify({
-                        action: 'copy',
-                        source: {
-                            path: sourceDirPath,
-                            name: fileName
-                        },
-                        target: {
-                            path: destination,
-                            name: fileName,
-                            isDirectory: true
-                        }
-                    })
-                });
-                results.push(result);
-            } catch (error) {
-                errors.push({ file: fileName, error: error.message });
-            }
-        }
-
-        if (errors.length > 0) {
-            const errorMsg = errors.map(e => `${e.file}: ${e.error}`).join(', ');
-            throw new Error(errorMsg);
-        }
-
-        return results;
+    async copyFiles(sources, destination, conflictPolicy) {
+        return this.batchAction('copy', sources, destination, conflictPolicy);
     }
 
-    async moveFiles(sources, destination) {
-        const results = [];
-        const errors = [];
+    async moveFiles(sources, destination, conflictPolicy) {
+        return this.batchAction('cut', sources, destination, conflictPolicy);
+    }
 
-        for (const sourcePath of sources) {
+    async batchAction(action, sourcePaths, destination, conflictPolicy = 'fail') {
+        // All the sources go into the destination directory in a single action
+        const sources = sourcePaths.map(sourcePath => {
             const pathParts = sourcePath.split('/');
             const fileName = pathParts.pop();
-            const sourceDirPath = pathParts.join('/');
+            return { path: pathParts.join('/'), name: fileName };
+        });
 
-            try {
-                const result = await this.request('/files/0/Actions', {
-                    method: 'POST',
-                    body: JSON.stringify({
-                        action: 'cut',
-                        source: {
-                            path: sourceDirPath,
-                            name: fileName
-                        },
-                        target: {
-                            path: destination,
-                            name: fileName,
-                            isDirectory: true
-                        }
-                    })
-                });
-                results.push(result);
-            } catch (error) {
-                errors.push({ file: fileName, error: error.message });
+        return this.runJob({
+            action: action,
+            sources: sources,
+            conflictPolicy: conflictPolicy,
+            target: {
+                path: destination,
+                name: '',
+                isDirectory: true
             }
-        }
+        });
+    }
 
-        if (errors.length > 0) {
-            const errorMsg = errors.map(e => `${e.file}: ${e.error}`).join(', ');
-            throw new Error(errorMsg);
+    // Runs a long action as a background job and polls it until it ends,
+    // so the request does not block until the server timeout
+    async runJob(action) {
+        const started = await this.request('/files/actions', {
+            method: 'POST',
+            body: JSON.stringify({ ...action, async: true })
+        });
+        for (;;) {
+            const list = await this.request('/files/jobs', {
+                method: 'POST',
+                body: JSON.stringify({ id: started.jobId })
+            });
+            const job = list.jobs && list.jobs[0];
+            if (job && job.ended) {
+                const response = job.response || {};
+                if (response.isError) {
+                    throw new Error(response.msg || 'Operation failed');
+                }
+                return response;
+            }
+            await new Promise(resolve => setTimeout(resolve, 500));
         }
-
-        return results;
     }
 
     async getFileInfo(path) {
@@ -907,6 +860,13 @@ class FileManager {
             this.confirmDelete();
         });
 
+        // Conflict modal
+        document.querySelectorAll('#conflictModal [data-policy]').forEach(btn => {
+            btn.addEventListener('click', (e) => {
+                this.pasteWithPolicy(e.currentTarget.dataset.policy);
+            });
+        });
+
         // Context menu
         document.querySelectorAll('.context-menu-item').forEach(item => {
             item.addEventListener('click', (e) => {
@@ -1022,15 +982,8 @@ class FileManager {
         const hasSelection = this.activePane && this.activePane.selectedFiles.size > 0;
         const hasClipboard = this.clipboard.files.length > 0;
 
-        // Check if selection contains only files (no directories) for download
-        let canDownload = false;
-        if (hasSelection) {
-            const selectedFiles = Array.from(this.activePane.selectedFiles);
-            canDownload = selectedFiles.every(name => {
-                const file = this.activePane.files.find(f => f.name === name);
-                return file && !file.isDirectory;
-            });
-        }
+        // Directories are downloaded as a zip archive
+        const canDownload = hasSelection;
 
         document.getElementById('copyBtn').disabled = !hasSelection;
         document.getElementById('cutBtn').disabled = !hasSelection;
@@ -1071,19 +1024,43 @@ class FileManager {
         this.updateToolbarButtons();
     }
 
-    async pasteFiles() {
+    pasteFiles() {
         if (!this.activePane || this.clipboard.files.length === 0) return;
 
+        // Ask how to resolve the names that already exist in the destination
+        const existing = new Set(this.activePane.files.map(file => file.name));
+        const conflicts = this.clipboard.files
+            .map(path => path.split('/').pop())
+            .filter(name => existing.has(name));
+        if (conflicts.length === 0) {
+            this.pasteWithPolicy('fail');
+            return;
+        }
+
+        let message;
+        if (conflicts.length === 1) {
+            message = `The destination already contains:\n\n${conflicts[0]}`;
+        } else if (conflicts.length <= 5) {
+            message = `The destination already contains the following ${conflicts.length} items:\n\n${conflicts.join('\n')}`;
+        } else {
+            message = `The destination already contains the following ${conflicts.length} items:\n\n${conflicts.slice(0, 3).join('\n')}\n... and ${conflicts.length - 3} more items`;
+        }
+        document.getElementById('conflictMessage').textContent = message;
+        this.showModal('conflictModal');
+    }
+
+    async pasteWithPolicy(policy) {
         const destination = this.activePane.currentPath;
         const destDisplay = destination === '/' ? 'root' : destination;
+        this.closeModal('conflictModal');
         this.showProgressModal(`Pasting files to ${destDisplay}...`);
 
         try {
             if (this.clipboard.operation === 'copy') {
-                await this.api.copyFiles(this.clipboard.files, destination);
+                await this.api.copyFiles(this.clipboard.files, destination, policy);
                 this.showStatus(`${this.clipboard.files.length} item(s) copied to ${destDisplay}`);
             } else {
-                await this.api.moveFiles(this.clipboard.files, destination);
+                await this.api.moveFiles(this.clipboard.files, destination, policy);
                 this.showStatus(`${this.clipboard.files.length} item(s) moved to ${destDisplay}`);
                 this.clipboard = { operation: null, files: [] };
                 this.updateClipboardStatus();
@@ -1205,7 +1182,7 @@ class FileManager {
             const pathParts = currentPath === '/' ? [] : currentPath.split('/').filter(p => p);
             const dirPath = pathParts.length > 0 ? '/' + pathParts.join('/') : '';
 
-            await this.api.request('/files/0/Actions', {
+            await this.api.request('/files/actions', {
                 method: 'POST',
                 body: JSON.stringify({
                     action: 'newFolder',
@@ -1260,14 +1237,10 @@ class FileManager {
             pasteItem.style.pointerEvents = this.clipboard.files.length > 0 ? '' : 'none';
         }
 
-        // Disable download if selection contains directories
+        // Disable download if nothing is selected
         const downloadItem = menu.querySelector('[data-action="download"]');
         if (downloadItem && this.activePane) {
-            const selectedFiles = Array.from(this.activePane.selectedFiles);
-            const canDownload = selectedFiles.every(name => {
-                const file = this.activePane.files.find(f => f.name === name);
-                return file && !file.isDirectory;
-            });
+            const canDownload = this.activePane.selectedFiles.size > 0;
             downloadItem.style.opacity = canDownload ? '1' : '0.5';
             downloadItem.style.pointerEvents = canDownload ? '' : 'none';
         }
@@ -1333,23 +1306,30 @@ class FileManager {
         console.log('Selected files:', selectedFiles);
         console.log('Available files in pane:', this.activePane.files);
 
-        const filesToDownload = selectedFiles.filter(name => {
+        const hasDirectories = selectedFiles.some(name => {
             const file = this.activePane.files.find(f => f.name === name);
-            console.log(`Checking file "${name}":`, file);
-            if (file) {
-                console.log(`  isDirectory: ${file.isDirectory}`);
-                console.log(`  Full file object:`, file);
-            }
-            return file && !file.isDirectory;
+            return file && file.isDirectory;
         });
 
-        console.log('Files to download:', filesToDownload);
-
-        if (filesToDownload.length === 0) {
-            this.showStatus('Cannot download directories. Please select files only.', 'error');
+        // Directories, together with the rest of the selection, are streamed as a single zip
+        if (hasDirectories) {
+            const paths = selectedFiles.map(name => this.activePane.getFilePath(name));
+            const archiveName = selectedFiles.length === 1 ? `${selectedFiles[0]}.zip` : 'download.zip';
+            this.showProgressModal(`Downloading ${archiveName}...`);
+            try {
+                await this.downloadFile(paths, archiveName);
+                this.showStatus(`Downloaded ${archiveName} successfully`);
+            } catch (error) {
+                console.error(`Failed to download ${archiveName}:`, error);
+                this.showStatus(`Failed to download ${archiveName}: ${error.message}`, 'error');
+            }
+            this.hideProgressModal();
             return;
         }
 
+        const filesToDownload = selectedFiles;
+        console.log('Files to download:', filesToDownload);
+
         // Download each file sequentially with progress indication
         for (let i = 0; i < filesToDownload.length; i++) {
             const fileName = filesToDownload[i];
@@ -1373,9 +1353,10 @@ class FileManager {
         this.showStatus(`Downloaded ${filesToDownload.length} file(s) successfully`);
     }
 
-    async downloadFile(filePath) {
-        const url = `/files/download?path=${encodeURIComponent(filePath)}`;
-        const fileName = filePath.split('/').pop();
+    async downloadFile(filePath, archiveName) {
+        const paths = Array.isArray(filePath) ? filePath : [filePath];
+        const url = '/files/download?' + paths.map(p => `path=${encodeURIComponent(p)}`).join('&');
+        const fileName = archiveName || paths[0].split('/').pop();
 
         try {
             const response = await fetch(url, {
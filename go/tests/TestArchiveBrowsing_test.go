@@ -0,0 +1,149 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/archives"
+	"github.com/saichler/l8nasfile/go/nas/config"
+	files2 "github.com/saichler/l8nasfile/go/nas/files"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+)
+
+func TestArchiveBrowsing(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, _ := os.Create(filepath.Join(root, "bundle.zip"))
+	zw := zip.NewWriter(zf)
+	w, _ := zw.Create("dir/a.txt")
+	w.Write([]byte("inside zip"))
+	w, _ = zw.Create("b.txt")
+	w.Write([]byte("b"))
+	w, _ = zw.Create("../../up.txt")
+	w.Write([]byte("up"))
+	w, _ = zw.Create("b.txt")
+	w.Write([]byte("b again"))
+	zw.Close()
+	zf.Close()
+	tf, _ := os.Create(filepath.Join(root, "bundle.tar.gz"))
+	gz := gzip.NewWriter(tf)
+	tw := tar.NewWriter(gz)
+	tw.WriteHeader(&tar.Header{Name: "docs/", Mode: 0755, Typeflag: tar.TypeDir})
+	tw.WriteHeader(&tar.Header{Name: "docs/readme.md", Mode: 0644, Size: 10, Typeflag: tar.TypeReg})
+	tw.Write([]byte("first copy"))
+	tw.WriteHeader(&tar.Header{Name: "docs/readme.md", Mode: 0644, Size: 10, Typeflag: tar.TypeReg})
+	tw.Write([]byte("inside tar"))
+	tw.Close()
+	gz.Close()
+	tf.Close()
+
+	service := &files2.FileService{}
+	listing := func(path, name string) map[string]*files.File {
+		req := &files.File{Path: path, Name: name, IsDirectory: true}
+		list, ok := service.Post(object.New(nil, req), nil).Element().(*files.FileList)
+		if !ok {
+			return nil
+		}
+		result := make(map[string]*files.File)
+		for _, f := range list.Fiels {
+			result[f.Name] = f
+		}
+		return result
+	}
+
+	list := listing("/", "data")
+	if !list["bundle.zip"].IsArchive || !list["bundle.tar.gz"].IsArchive {
+		t.Fatal("expected the archives to be marked")
+	}
+	list = listing("/data", "bundle.zip")
+	if len(list) != 3 || !list["dir"].IsDirectory || list["b.txt"].Size != 7 || list["up.txt"] == nil {
+		t.Fatal("unexpected zip root listing", list)
+	}
+	list = listing("/data/bundle.zip", "dir")
+	if len(list) != 1 || list["a.txt"].Path != "/data/bundle.zip/dir" || list["a.txt"].Size != 10 {
+		t.Fatal("unexpected zip directory listing", list)
+	}
+	list = listing("/data/bundle.tar.gz", "docs")
+	if len(list) != 1 || list["readme.md"].Size != 10 {
+		t.Fatal("unexpected tar directory listing", list)
+	}
+	if listing("/data/bundle.zip", "missing") != nil {
+		t.Fatal("expected a missing directory to fail")
+	}
+
+	download := func(path string) (int, string) {
+		w := httptest.NewRecorder()
+		actions.DownloadHandler(w, httptest.NewRequest("GET", "/files/download?path="+path, nil), nil)
+		return w.Code, w.Body.String()
+	}
+	if code, body := download("/data/bundle.zip/dir/a.txt"); code != 200 || body != "inside zip" {
+		t.Fatal("unexpected zip member download", code, body)
+	}
+	if code, body := download("/data/bundle.tar.gz/docs/readme.md"); code != 200 || body != "inside tar" {
+		t.Fatal("unexpected tar member download", code, body)
+	}
+	if code, _ := download("/data/bundle.zip/dir"); code != 404 {
+		t.Fatal("expected a directory member to fail", code)
+	}
+	if code, _ := download("/data/bundle.zip/nothing.txt"); code != 404 {
+		t.Fatal("expected a missing member to fail", code)
+	}
+
+	// Of duplicate members the last one is listed and downloaded
+	if code, body := download("/data/bundle.zip/b.txt"); code != 200 || body != "b again" {
+		t.Fatal("expected the last duplicate to be downloaded", code, body)
+	}
+
+	rec := httptest.NewRecorder()
+	actions.DownloadHandler(rec, httptest.NewRequest("GET", "/files/download?path=/data/bundle.zip/b.txt", nil), nil)
+	tag := rec.Header().Get("ETag")
+	if !regexp.MustCompile(`^"[0-9a-f]+"$`).MatchString(tag) {
+		t.Fatal("expected a single quoted entity tag", tag)
+	}
+	req := httptest.NewRequest("GET", "/files/download?path=/data/bundle.zip/b.txt", nil)
+	req.Header.Set("If-None-Match", tag)
+	rec = httptest.NewRecorder()
+	actions.DownloadHandler(rec, req, nil)
+	if rec.Code != 304 {
+		t.Fatal("expected the member to be not modified", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	actions.DownloadHandler(rec, httptest.NewRequest("GET", "/files/download?path=/data/bundle.zip/dir/a.txt", nil), nil)
+	if rec.Header().Get("ETag") == tag {
+		t.Fatal("expected the members to have different entity tags")
+	}
+
+	// Archives with more entries than the maximum are not browsed
+	archives.Configure(&config.ArchiveConfig{MaxEntries: 2})
+	defer archives.Configure(config.Default().Archive)
+	if listing("/data", "bundle.zip") != nil || listing("/data", "bundle.tar.gz") != nil {
+		t.Fatal("expected the entry count limit to be enforced")
+	}
+}
@@ -0,0 +1,233 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+func TestArchives(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "docs", "sub"), 0755)
+	os.WriteFile(filepath.Join(root, "docs", "a.txt"), []byte("hello"), 0644)
+	os.WriteFile(filepath.Join(root, "docs", "sub", "b.txt"), []byte("world"), 0644)
+	os.WriteFile(filepath.Join(root, "c.txt"), []byte("!"), 0644)
+
+	sources := []*files.File{{Path: "/data", Name: "docs"}, {Path: "/data", Name: "c.txt"}}
+
+	for _, name := range []string{"out.zip", "out.tar", "out.tar.gz", "out.tar.zst"} {
+		resp := postAction(t, &files.Action{Action: files.ActionType_compress, Sources: sources,
+			Target: &files.File{Path: "/data", Name: name}})
+		if resp.IsError {
+			t.Fatal(name, resp.Msg)
+		}
+		resp = postAction(t, &files.Action{Action: files.ActionType_extract, Source: &files.File{Path: "/data", Name: name},
+			Target: &files.File{Path: "/data/extracted", Name: name}})
+		if resp.IsError {
+			t.Fatal(name, resp.Msg, resp.Results)
+		}
+		data, err := os.ReadFile(filepath.Join(root, "extracted", name, "docs", "sub", "b.txt"))
+		if err != nil || string(data) != "world" {
+			t.Fatal(name, "unexpected extracted content", err)
+		}
+		if _, err = os.Stat(filepath.Join(root, "extracted", name, "c.txt")); err != nil {
+			t.Fatal(name, err)
+		}
+	}
+	resp := postAction(t, &files.Action{Action: files.ActionType_compress, Sources: sources,
+		Target: &files.File{Path: "/data/docs", Name: "inside.zip"}})
+	if !resp.IsError {
+		t.Fatal("expected an archive inside its source to be rejected")
+	}
+	resp = postAction(t, &files.Action{Action: files.ActionType_compress, Sources: sources,
+		Target: &files.File{Path: "/data", Name: "out.rar"}})
+	if !resp.IsError {
+		t.Fatal("expected an unknown format to be rejected")
+	}
+
+	// Zip slip and symbolic link escapes are skipped
+	evil, _ := os.Create(filepath.Join(root, "evil.tar"))
+	tw := tar.NewWriter(evil)
+	tw.WriteHeader(&tar.Header{Name: "../escaped.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	tw.Write([]byte("x"))
+	tw.WriteHeader(&tar.Header{Name: "up", Linkname: "../..", Typeflag: tar.TypeSymlink})
+	tw.WriteHeader(&tar.Header{Name: "abs", Linkname: "/etc", Typeflag: tar.TypeSymlink})
+	tw.WriteHeader(&tar.Header{Name: "here", Linkname: ".", Typeflag: tar.TypeSymlink})
+	tw.WriteHeader(&tar.Header{Name: "here/../escaped2.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	tw.Write([]byte("x"))
+	tw.WriteHeader(&tar.Header{Name: "ok.txt", Mode: 0644, Size: 2, Typeflag: tar.TypeReg})
+	tw.Write([]byte("ok"))
+	tw.Close()
+	evil.Close()
+	resp = postAction(t, &files.Action{Action: files.ActionType_extract, Source: &files.File{Path: "/data", Name: "evil.tar"},
+		Target: &files.File{Path: "/data/evil", Name: "out"}})
+	if !resp.IsError {
+		t.Fatal("expected the escaping entries to be reported")
+	}
+	if _, err = os.Lstat(filepath.Join(root, "evil", "escaped.txt")); err == nil {
+		t.Fatal("zip slip entry was extracted")
+	}
+	if _, err = os.Lstat(filepath.Join(root, "evil", "out", "up")); err == nil {
+		t.Fatal("escaping link was extracted")
+	}
+	if _, err = os.Lstat(filepath.Join(root, "evil", "out", "abs")); err == nil {
+		t.Fatal("absolute link was extracted")
+	}
+	if _, err = os.Lstat(filepath.Join(root, "evil", "out", "ok.txt")); err != nil {
+		t.Fatal("expected the valid entries to be extracted", err)
+	}
+
+	// Bombs are stopped by the limits
+	bomb, _ := os.Create(filepath.Join(root, "bomb.zip"))
+	zw := zip.NewWriter(bomb)
+	for _, name := range []string{"1", "2", "3"} {
+		w, _ := zw.Create(name)
+		w.Write(make([]byte, 1000))
+	}
+	zw.Close()
+	bomb.Close()
+	actions.ConfigureArchives(&config.ArchiveConfig{MaxSize: 2500})
+	defer actions.ConfigureArchives(config.Default().Archive)
+	resp = postAction(t, &files.Action{Action: files.ActionType_extract, Source: &files.File{Path: "/data", Name: "bomb.zip"},
+		Target: &files.File{Path: "/data", Name: "bomb"}})
+	if !resp.IsError {
+		t.Fatal("expected the expanded size limit to be enforced")
+	}
+	actions.ConfigureArchives(&config.ArchiveConfig{MaxEntries: 2})
+	resp = postAction(t, &files.Action{Action: files.ActionType_extract, Source: &files.File{Path: "/data", Name: "bomb.zip"},
+		Target: &files.File{Path: "/data", Name: "bomb2"}})
+	if !resp.IsError {
+		t.Fatal("expected the entry count limit to be enforced")
+	}
+}
+
+func TestExtractIntoShareRoot(t *testing.T) {
+	root := shareDir(t)
+	modified := time.Now().Add(-time.Hour).Truncate(time.Second)
+	os.Mkdir(filepath.Join(root, "docs"), 0755)
+	os.Chtimes(filepath.Join(root, "docs"), modified, modified)
+
+	archived := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	file, _ := os.Create(filepath.Join(root, "root.tar"))
+	tw := tar.NewWriter(file)
+	tw.WriteHeader(&tar.Header{Name: trash.Dir + "/files/x", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+	tw.Write([]byte("x"))
+	tw.WriteHeader(&tar.Header{Name: "hidden", Linkname: trash.Dir, Typeflag: tar.TypeSymlink})
+	tw.WriteHeader(&tar.Header{Name: "docs/", Mode: 0700, ModTime: archived, Typeflag: tar.TypeDir})
+	tw.WriteHeader(&tar.Header{Name: "new/", Mode: 0750, ModTime: archived, Typeflag: tar.TypeDir})
+	tw.WriteHeader(&tar.Header{Name: "new/a.txt", Mode: 0644, Size: 1, ModTime: archived, Typeflag: tar.TypeReg})
+	tw.Write([]byte("a"))
+	tw.Close()
+	file.Close()
+
+	resp := postAction(t, &files.Action{Action: files.ActionType_extract, Source: &files.File{Path: "/data", Name: "root.tar"},
+		Target: &files.File{Path: "/", Name: "data"}})
+	if !resp.IsError {
+		t.Fatal("expected the reserved entries to be reported")
+	}
+	if _, err := os.Lstat(filepath.Join(root, trash.Dir)); err == nil {
+		t.Fatal("expected nothing to be extracted into the trash")
+	}
+	if _, err := os.Lstat(filepath.Join(root, "hidden")); err == nil {
+		t.Fatal("expected the link into the trash to be rejected")
+	}
+
+	// Only the directories created by the extraction take the archived mode and time
+	info, err := os.Stat(filepath.Join(root, "docs"))
+	if err != nil || info.Mode().Perm() != 0755 || !info.ModTime().Equal(modified) {
+		t.Fatal("expected the existing directory to be kept", info.Mode(), info.ModTime(), err)
+	}
+	info, err = os.Stat(filepath.Join(root, "new"))
+	if err != nil || info.Mode().Perm() != 0750 || !info.ModTime().Equal(archived) {
+		t.Fatal("expected the created directory to be restored", info.Mode(), info.ModTime(), err)
+	}
+}
+
+func TestArchiveDownloadNames(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "a", "x"), 0755)
+	os.MkdirAll(filepath.Join(root, "b", "x"), 0755)
+	os.WriteFile(filepath.Join(root, "a", "x", "one"), []byte("one"), 0644)
+	os.WriteFile(filepath.Join(root, "b", "x", "two"), []byte("two"), 0644)
+	os.WriteFile(filepath.Join(root, "a", "f.txt"), []byte("a"), 0644)
+	os.WriteFile(filepath.Join(root, "b", "f.txt"), []byte("b"), 0644)
+
+	w := httptest.NewRecorder()
+	actions.DownloadHandler(w, httptest.NewRequest("GET",
+		"/files/download?path=/data/a/x&path=/data/b/x&path=/data/a/f.txt&path=/data/b/f.txt", nil), nil)
+	reader, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, f := range reader.File {
+		if names[f.Name] {
+			t.Fatal("duplicate entry", f.Name)
+		}
+		names[f.Name] = true
+	}
+	for _, name := range []string{"x/one", "x (1)/two", "f.txt", "f (1).txt"} {
+		if !names[name] {
+			t.Fatal("expected entry", name, names)
+		}
+	}
+}
+
+func TestArchiveShareRoot(t *testing.T) {
+	root := shareDir(t)
+	os.WriteFile(filepath.Join(root, "f.txt"), []byte("f"), 0644)
+	os.MkdirAll(filepath.Join(root, trash.Dir, "1"), 0755)
+	os.WriteFile(filepath.Join(root, trash.Dir, "1", "deleted.txt"), []byte("deleted"), 0644)
+
+	w := httptest.NewRecorder()
+	actions.DownloadHandler(w, httptest.NewRequest("GET", "/files/download?path=/data", nil), nil)
+	reader, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool)
+	for _, f := range reader.File {
+		if strings.Contains(f.Name, trash.Dir) {
+			t.Fatal("the trash must not be archived", f.Name)
+		}
+		names[f.Name] = true
+	}
+	if !names["data/f.txt"] {
+		t.Fatal("expected the share root to be archived under the share name", names)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+// copyBatch copies a.txt, a missing file and b.txt into the target directory.
+func copyBatch(t *testing.T, root, target string, stopOnError bool) *files.ActionResponse {
+	os.Mkdir(filepath.Join(root, target), 0755)
+	return postAction(t, &files.Action{
+		Action: files.ActionType_copy,
+		Sources: []*files.File{
+			{Path: "/data", Name: "a.txt"},
+			{Path: "/data", Name: "missing.txt"},
+			{Path: "/data", Name: "b.txt"},
+		},
+		Target:      &files.File{Path: "/data", Name: target},
+		StopOnError: stopOnError,
+	})
+}
+
+func TestBatchFailure(t *testing.T) {
+	root := shareDir(t)
+	os.WriteFile(filepath.Join(root, "a.txt"), []byte("a"), 0644)
+	os.WriteFile(filepath.Join(root, "b.txt"), []byte("b"), 0644)
+
+	// Without stopOnError the failed source is reported and the others are copied
+	resp := copyBatch(t, root, "all", false)
+	if !resp.IsError || resp.Msg != "1 of 3 items failed" || len(resp.Items) != 3 {
+		t.Fatal("unexpected batch response", resp)
+	}
+	if resp.Items[0].IsError || !resp.Items[1].IsError || resp.Items[2].IsError || resp.Items[1].Path != "/data/missing.txt" {
+		t.Fatal("unexpected batch items", resp.Items)
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if _, err := os.Stat(filepath.Join(root, "all", name)); err != nil {
+			t.Fatal("expected the source to be copied", name, err)
+		}
+	}
+
+	// With stopOnError the batch stops at the failed source
+	resp = copyBatch(t, root, "stopped", true)
+	if !resp.IsError || len(resp.Items) != 2 || !resp.Items[1].IsError {
+		t.Fatal("expected the batch to stop at the failed source", resp)
+	}
+	if _, err := os.Stat(filepath.Join(root, "stopped", "a.txt")); err != nil {
+		t.Fatal("expected the first source to be copied", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "stopped", "b.txt")); !os.IsNotExist(err) {
+		t.Fatal("expected the sources after the failure not to be copied", err)
+	}
+}
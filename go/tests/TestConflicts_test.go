@@ -0,0 +1,170 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+func TestConflictPolicies(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name        string
+		action      files.ActionType
+		policy      files.ConflictPolicy
+		targetNewer bool
+		isError     bool
+		content     string
+		kept        string
+		resolution  string
+	}{
+		{"fail", files.ActionType_copy, files.ConflictPolicy_fail, false, true, "old", "", "failed"},
+		{"skip", files.ActionType_copy, files.ConflictPolicy_skip, false, false, "old", "", "skipped"},
+		{"keepBoth", files.ActionType_copy, files.ConflictPolicy_keepBoth, false, false, "old", "new", "kept both"},
+		{"overwrite", files.ActionType_copy, files.ConflictPolicy_overwrite, false, false, "new", "", "overwritten"},
+		{"overwriteIfNewer source newer", files.ActionType_copy, files.ConflictPolicy_overwriteIfNewer, false, false, "new", "", "overwritten, source is newer"},
+		{"overwriteIfNewer target newer", files.ActionType_copy, files.ConflictPolicy_overwriteIfNewer, true, false, "old", "", "skipped, target is newer"},
+		{"cut skip", files.ActionType_cut, files.ConflictPolicy_skip, false, false, "old", "", "skipped"},
+		{"cut overwrite", files.ActionType_cut, files.ConflictPolicy_overwrite, false, false, "new", "", "overwritten"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			os.MkdirAll(filepath.Join(root, "src"), 0755)
+			os.MkdirAll(filepath.Join(root, "dst"), 0755)
+			src := filepath.Join(root, "src", "f.txt")
+			dst := filepath.Join(root, "dst", "f.txt")
+			os.WriteFile(src, []byte("new"), 0644)
+			os.WriteFile(dst, []byte("old"), 0644)
+			if test.targetNewer {
+				os.Chtimes(src, old, old)
+			} else {
+				os.Chtimes(dst, old, old)
+			}
+
+			resp := actions.Run(&files.Action{Action: test.action, ConflictPolicy: test.policy,
+				Source: &files.File{Path: "/data/src", Name: "f.txt"},
+				Target: &files.File{Path: "/data", Name: "dst"}}, "")
+			if resp.IsError != test.isError {
+				t.Fatal("unexpected response", resp)
+			}
+			if len(resp.Conflicts) != 1 || !strings.HasPrefix(resp.Conflicts[0].Resolution, test.resolution) {
+				t.Fatal("unexpected conflicts", resp.Conflicts)
+			}
+			data, _ := os.ReadFile(dst)
+			if string(data) != test.content {
+				t.Fatal("unexpected target content", string(data))
+			}
+			kept, _ := os.ReadFile(filepath.Join(root, "dst", "f (1).txt"))
+			if string(kept) != test.kept {
+				t.Fatal("unexpected kept copy", string(kept))
+			}
+			_, err = os.Stat(src)
+			moved := test.action == files.ActionType_cut && test.content == "new"
+			if moved != os.IsNotExist(err) {
+				t.Fatal("unexpected source state", err)
+			}
+		})
+	}
+}
+
+func TestCopyDestination(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   *files.File
+		policy   files.ConflictPolicy
+		expected []string
+	}{
+		// An existing directory target receives the source, whatever its name
+		{"into target", &files.File{Path: "/data", Name: "dst"}, files.ConflictPolicy_fail,
+			[]string{"dst/docs/a.txt"}},
+		{"into same name", &files.File{Path: "/data/dst", Name: "docs"}, files.ConflictPolicy_fail,
+			[]string{"dst/docs/docs/a.txt", "dst/docs/other.txt"}},
+		{"into other name", &files.File{Path: "/data/dst", Name: "other"}, files.ConflictPolicy_fail,
+			[]string{"dst/other/docs/a.txt"}},
+		{"new path", &files.File{Path: "/data/dst", Name: "renamed"}, files.ConflictPolicy_fail,
+			[]string{"dst/renamed/a.txt"}},
+		// The conflict policy applies to the computed destination
+		{"merge", &files.File{Path: "/data", Name: "dst"}, files.ConflictPolicy_overwrite,
+			[]string{"dst/docs/a.txt", "dst/docs/other.txt"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			os.MkdirAll(filepath.Join(root, "src", "docs"), 0755)
+			os.WriteFile(filepath.Join(root, "src", "docs", "a.txt"), []byte("a"), 0644)
+			os.MkdirAll(filepath.Join(root, "dst", "other"), 0755)
+			if test.name != "into target" {
+				os.MkdirAll(filepath.Join(root, "dst", "docs"), 0755)
+				os.WriteFile(filepath.Join(root, "dst", "docs", "other.txt"), []byte("o"), 0644)
+			}
+
+			resp := actions.Run(&files.Action{Action: files.ActionType_copy, ConflictPolicy: test.policy,
+				Source: &files.File{Path: "/data/src", Name: "docs"}, Target: test.target}, "")
+			if resp.IsError {
+				t.Fatal("unexpected error", resp)
+			}
+			for _, path := range test.expected {
+				if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(path))); err != nil {
+					t.Fatal("expected", path, err)
+				}
+			}
+		})
+	}
+
+	// A batch copies every source into the target directory
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "src", "docs"), 0755)
+	os.MkdirAll(filepath.Join(root, "dst", "docs"), 0755)
+	os.WriteFile(filepath.Join(root, "src", "f.txt"), []byte("f"), 0644)
+	sources := []*files.File{{Path: "/data/src", Name: "docs"}, {Path: "/data/src", Name: "f.txt"}}
+	resp := actions.Run(&files.Action{Action: files.ActionType_copy, ConflictPolicy: files.ConflictPolicy_overwrite,
+		Sources: sources, Target: &files.File{Path: "/data", Name: "dst"}}, "")
+	if resp.IsError || len(resp.Items) != 2 {
+		t.Fatal("unexpected batch response", resp)
+	}
+	if _, err := os.Stat(filepath.Join(root, "dst", "f.txt")); err != nil {
+		t.Fatal("expected the file in the target", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "dst", "docs", "docs")); err == nil {
+		t.Fatal("expected the directory to be merged, not nested")
+	}
+	resp = actions.Run(&files.Action{Action: files.ActionType_copy, Sources: sources,
+		Target: &files.File{Path: "/data", Name: "missing"}}, "")
+	if !resp.IsError {
+		t.Fatal("expected a missing batch target to fail")
+	}
+}
@@ -0,0 +1,157 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+)
+
+// download requests the query from the download handler with the headers.
+func download(query string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/files/download?"+query, nil)
+	for name, value := range headers {
+		r.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	actions.DownloadHandler(w, r, nil)
+	return w
+}
+
+func TestDownloadRanges(t *testing.T) {
+	root := shareDir(t)
+	os.WriteFile(filepath.Join(root, "digits.txt"), []byte("0123456789"), 0644)
+
+	w := download("path=/data/digits.txt", nil)
+	if w.Code != http.StatusOK || w.Body.String() != "0123456789" || w.Header().Get("Accept-Ranges") != "bytes" {
+		t.Fatal("unexpected download", w.Code, w.Body.String(), w.Header())
+	}
+	if cd := w.Header().Get("Content-Disposition"); cd != "attachment; filename*=UTF-8''digits.txt" {
+		t.Fatal("unexpected disposition", cd)
+	}
+
+	w = download("path=/data/digits.txt", map[string]string{"Range": "bytes=2-5"})
+	if w.Code != http.StatusPartialContent || w.Body.String() != "2345" || w.Header().Get("Content-Range") != "bytes 2-5/10" {
+		t.Fatal("unexpected range", w.Code, w.Body.String(), w.Header())
+	}
+
+	w = download("path=/data/digits.txt", map[string]string{"Range": "bytes=0-1,8-"})
+	mediaType, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if w.Code != http.StatusPartialContent || err != nil || mediaType != "multipart/byteranges" {
+		t.Fatal("unexpected multi range", w.Code, w.Header(), err)
+	}
+	reader := multipart.NewReader(w.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(part)
+		parts = append(parts, part.Header.Get("Content-Range")+" "+string(data))
+	}
+	if strings.Join(parts, ",") != "bytes 0-1/10 01,bytes 8-9/10 89" {
+		t.Fatal("unexpected parts", parts)
+	}
+
+	w = download("path=/data/digits.txt", map[string]string{"Range": "bytes=20-"})
+	if w.Code != http.StatusRequestedRangeNotSatisfiable {
+		t.Fatal("expected an unsatisfiable range", w.Code)
+	}
+}
+
+func TestDownloadConditions(t *testing.T) {
+	root := shareDir(t)
+	path := filepath.Join(root, "digits.txt")
+	os.WriteFile(path, []byte("0123456789"), 0644)
+	modified := time.Now().Add(-time.Hour).Truncate(time.Second)
+	os.Chtimes(path, modified, modified)
+
+	tag := download("path=/data/digits.txt", nil).Header().Get("ETag")
+	if tag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	w := download("path=/data/digits.txt", map[string]string{"If-None-Match": tag})
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatal("expected not modified for a matching tag", w.Code)
+	}
+	w = download("path=/data/digits.txt", map[string]string{"If-None-Match": "\"other\""})
+	if w.Code != http.StatusOK {
+		t.Fatal("expected the content for another tag", w.Code)
+	}
+
+	w = download("path=/data/digits.txt", map[string]string{"If-Modified-Since": modified.UTC().Format(http.TimeFormat)})
+	if w.Code != http.StatusNotModified {
+		t.Fatal("expected not modified since the modification time", w.Code)
+	}
+	w = download("path=/data/digits.txt", map[string]string{"If-Modified-Since": modified.Add(-time.Minute).UTC().Format(http.TimeFormat)})
+	if w.Code != http.StatusOK {
+		t.Fatal("expected the content modified since", w.Code)
+	}
+
+	// If-Range serves the range only while the validator still matches
+	w = download("path=/data/digits.txt", map[string]string{"Range": "bytes=5-", "If-Range": tag})
+	if w.Code != http.StatusPartialContent || w.Body.String() != "56789" {
+		t.Fatal("expected the range for a matching If-Range", w.Code, w.Body.String())
+	}
+	os.WriteFile(path, []byte("abcdefghijk"), 0644)
+	w = download("path=/data/digits.txt", map[string]string{"Range": "bytes=5-", "If-Range": tag})
+	if w.Code != http.StatusOK || w.Body.String() != "abcdefghijk" {
+		t.Fatal("expected the whole file for a stale If-Range", w.Code, w.Body.String())
+	}
+}
+
+func TestDownloadInline(t *testing.T) {
+	root := shareDir(t)
+	os.WriteFile(filepath.Join(root, "page.html"), []byte("<script>alert(1)</script>"), 0644)
+	os.WriteFile(filepath.Join(root, "image.svg"), []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"), 0644)
+	os.WriteFile(filepath.Join(root, "notes.txt"), []byte("hello"), 0644)
+
+	for _, name := range []string{"page.html", "image.svg"} {
+		w := download("path=/data/"+name+"&disposition=inline", nil)
+		if ct := w.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+			t.Fatal("active content must be served as text", name, ct)
+		}
+		if cd := w.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, "inline;") {
+			t.Fatal("unexpected disposition", name, cd)
+		}
+		if w.Header().Get("X-Content-Type-Options") != "nosniff" {
+			t.Fatal("expected nosniff", name)
+		}
+	}
+	w := download("path=/data/notes.txt&disposition=inline", nil)
+	if ct := w.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" || w.Body.String() != "hello" {
+		t.Fatal("unexpected inline text", ct, w.Body.String())
+	}
+	w = download("path=/data/image.svg", nil)
+	if ct := w.Header().Get("Content-Type"); ct != "image/svg+xml" {
+		t.Fatal("unexpected attachment type", ct)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+)
+
+// postAction posts the action to the action service and returns its response.
+func postAction(t *testing.T, ac *files.Action) *files.ActionResponse {
+	resp, ok := (&actions.ActionService{}).Post(object.New(nil, ac), nil).Element().(*files.ActionResponse)
+	if !ok {
+		t.Fatal("expected an action response")
+	}
+	return resp
+}
+
+// shareDir returns a temporary directory shared as "data".
+func shareDir(t *testing.T) string {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// makeTree creates dir/docs with a file, a sub directory and a relative
+// symbolic link, all modified an hour ago.
+func makeTree(t *testing.T, dir string) time.Time {
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	docs := filepath.Join(dir, "docs")
+	os.MkdirAll(filepath.Join(docs, "sub"), 0755)
+	os.WriteFile(filepath.Join(docs, "a.txt"), []byte("hello"), 0600)
+	os.WriteFile(filepath.Join(docs, "sub", "b.txt"), []byte("world"), 0644)
+	err := os.Symlink("sub/b.txt", filepath.Join(docs, "link"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"a.txt", "sub/b.txt", "sub", ""} {
+		os.Chtimes(filepath.Join(docs, path), old, old)
+	}
+	return old
+}
+
+// checkTree verifies the tree created by makeTree was reproduced at docs.
+func checkTree(t *testing.T, docs string, modified time.Time) {
+	data, err := os.ReadFile(filepath.Join(docs, "sub", "b.txt"))
+	if err != nil || string(data) != "world" {
+		t.Fatal("unexpected content", string(data), err)
+	}
+	info, err := os.Stat(filepath.Join(docs, "a.txt"))
+	if err != nil || info.Mode().Perm() != 0600 || !info.ModTime().Equal(modified) {
+		t.Fatal("unexpected file info", info, err)
+	}
+	info, err = os.Stat(docs)
+	if err != nil || !info.ModTime().Equal(modified) {
+		t.Fatal("unexpected directory info", info, err)
+	}
+	link, err := os.Readlink(filepath.Join(docs, "link"))
+	if err != nil || link != "sub/b.txt" {
+		t.Fatal("expected the link to be re-created", link, err)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	root := shareDir(t)
+	modified := makeTree(t, root)
+	os.Mkdir(filepath.Join(root, "dst"), 0755)
+
+	// An existing directory receives the source under its own name
+	resp := postAction(t, &files.Action{Action: files.ActionType_copy,
+		Source: &files.File{Path: "/data", Name: "docs"}, Target: &files.File{Path: "/data", Name: "dst"}})
+	if resp.IsError {
+		t.Fatal(resp.Msg)
+	}
+	checkTree(t, filepath.Join(root, "dst", "docs"), modified)
+	checkTree(t, filepath.Join(root, "docs"), modified)
+
+	resp = postAction(t, &files.Action{Action: files.ActionType_copy,
+		Source: &files.File{Path: "/data", Name: "docs"}, Target: &files.File{Path: "/data", Name: "copy"}})
+	if resp.IsError {
+		t.Fatal(resp.Msg)
+	}
+	checkTree(t, filepath.Join(root, "copy"), modified)
+
+	resp = postAction(t, &files.Action{Action: files.ActionType_copy,
+		Source: &files.File{Path: "/data", Name: "docs"}, Target: &files.File{Path: "/data/docs", Name: "sub"}})
+	if !resp.IsError {
+		t.Fatal("expected copying a directory into itself to fail")
+	}
+}
+
+func TestMove(t *testing.T) {
+	root := shareDir(t)
+	modified := makeTree(t, root)
+	resp := postAction(t, &files.Action{Action: files.ActionType_cut,
+		Source: &files.File{Path: "/data", Name: "docs"}, Target: &files.File{Path: "/data", Name: "moved"}})
+	if resp.IsError {
+		t.Fatal(resp.Msg)
+	}
+	checkTree(t, filepath.Join(root, "moved"), modified)
+	if _, err := os.Lstat(filepath.Join(root, "docs")); !os.IsNotExist(err) {
+		t.Fatal("expected the source to be moved", err)
+	}
+}
+
+func TestMoveAcrossFileSystems(t *testing.T) {
+	root := t.TempDir()
+	other, err := os.MkdirTemp("/dev/shm", "nas")
+	if err != nil {
+		t.Skip("no second file system", err)
+	}
+	defer os.RemoveAll(other)
+	err = shares.Configure([]*shares.Share{{Name: "data", Root: root}, {Name: "shm", Root: other}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootInfo, _ := os.Stat(root)
+	otherInfo, _ := os.Stat(other)
+	if rootInfo.Sys().(*syscall.Stat_t).Dev == otherInfo.Sys().(*syscall.Stat_t).Dev {
+		t.Skip("the temporary directories are on the same file system")
+	}
+
+	// The rename fails with EXDEV, so the tree is copied and then removed
+	modified := makeTree(t, other)
+	resp := postAction(t, &files.Action{Action: files.ActionType_cut,
+		Source: &files.File{Path: "/shm", Name: "docs"}, Target: &files.File{Path: "/data", Name: "moved"}})
+	if resp.IsError {
+		t.Fatal(resp.Msg, resp.Results)
+	}
+	checkTree(t, filepath.Join(root, "moved"), modified)
+	if _, err := os.Lstat(filepath.Join(other, "docs")); !os.IsNotExist(err) {
+		t.Fatal("expected the source to be removed", err)
+	}
+}
+
+func TestDeleteAndNewFolder(t *testing.T) {
+	root := shareDir(t)
+	makeTree(t, root)
+	resp := postAction(t, &files.Action{Action: files.ActionType_delete, Source: &files.File{Path: "/data", Name: "docs"}})
+	if resp.IsError {
+		t.Fatal(resp.Msg)
+	}
+	if _, err := os.Lstat(filepath.Join(root, "docs")); !os.IsNotExist(err) {
+		t.Fatal("expected the tree to be deleted", err)
+	}
+	resp = postAction(t, &files.Action{Action: files.ActionType_delete, Source: &files.File{Path: "/", Name: "data"}})
+	if !resp.IsError {
+		t.Fatal("expected deleting the share root to fail")
+	}
+
+	resp = postAction(t, &files.Action{Action: files.ActionType_newFolder, Source: &files.File{Path: "/data", Name: "a/b"}})
+	if resp.IsError {
+		t.Fatal(resp.Msg)
+	}
+	if info, err := os.Stat(filepath.Join(root, "a", "b")); err != nil || !info.IsDir() {
+		t.Fatal("expected the folder to be created", err)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/hashes"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+func TestHashes(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "tree", "sub"), 0755)
+	os.MkdirAll(filepath.Join(root, trash.Dir), 0755)
+	os.WriteFile(filepath.Join(root, "abc.txt"), []byte("abc"), 0644)
+	os.WriteFile(filepath.Join(root, "tree", "one"), []byte("one"), 0644)
+	os.WriteFile(filepath.Join(root, "tree", "sub", "two"), []byte("two"), 0644)
+	os.WriteFile(filepath.Join(root, trash.Dir, "gone"), []byte("gone"), 0644)
+	os.Symlink("one", filepath.Join(root, "tree", "link"))
+
+	start := func(req *files.HashRequest) *files.HashResult {
+		calc, err := hashes.Start(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		calc.Wait()
+		return calc.Result()
+	}
+
+	result := start(&files.HashRequest{Paths: []string{"/data/abc.txt"}, Algorithms: []files.HashAlgorithm{
+		files.HashAlgorithm_sha256, files.HashAlgorithm_sha1, files.HashAlgorithm_md5, files.HashAlgorithm_crc32,
+		files.HashAlgorithm_blake2b, files.HashAlgorithm_blake2b256}})
+	expected := []string{
+		"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"a9993e364706816aba3e25717850c26c9cd0d89d",
+		"900150983cd24fb0d6963f7d28e17f72",
+		"352441c2",
+		"ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923",
+		"bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319",
+	}
+	if !result.Done || len(result.Files) != 1 || len(result.Files[0].Digests) != len(expected) {
+		t.Fatal("Unexpected result", result)
+	}
+	for i, digest := range result.Files[0].Digests {
+		if digest.Value != expected[i] {
+			t.Fatal("Unexpected digest", digest.Algorithm, digest.Value)
+		}
+	}
+	if result.HashedBytes != 3 || result.Files[0].Hashed != 3 || result.HashedFiles != 1 {
+		t.Fatal("Unexpected progress", result)
+	}
+
+	// The tree manifest skips the trash and the links
+	result = start(&files.HashRequest{Paths: []string{"/data/tree"}})
+	if result.TotalFiles != 2 || result.HashedFiles != 2 || result.TotalBytes != 6 {
+		t.Fatal("Unexpected tree totals", result)
+	}
+	manifest := result.Manifest
+	if !strings.Contains(manifest, "  one\n") || !strings.Contains(manifest, "  sub/two\n") ||
+		strings.Contains(manifest, "link") {
+		t.Fatal("Unexpected manifest", manifest)
+	}
+	result = start(&files.HashRequest{Paths: []string{"/data"}})
+	if strings.Contains(result.Manifest, "gone") {
+		t.Fatal("Expected the trash to be skipped", result.Manifest)
+	}
+
+	// Verify the tree against its own manifest after changes
+	os.WriteFile(filepath.Join(root, "tree", "one"), []byte("changed"), 0644)
+	os.WriteFile(filepath.Join(root, "tree", "three"), []byte("three"), 0644)
+	manifest += "# a comment\nMD5 (sub/missing) = 900150983cd24fb0d6963f7d28e17f72\n"
+	result = start(&files.HashRequest{Paths: []string{"/data/tree"}, Manifest: manifest})
+	if result.Matched != 1 || result.Mismatched != 1 || result.Missing != 1 || result.Unlisted != 1 {
+		t.Fatal("Unexpected verification", result)
+	}
+	status := make(map[string]files.HashStatus)
+	for _, f := range result.Files {
+		status[f.Name] = f.Status
+	}
+	if status["sub/two"] != files.HashStatus_matched || status["one"] != files.HashStatus_mismatched ||
+		status["sub/missing"] != files.HashStatus_missing || status["three"] != files.HashStatus_unlisted {
+		t.Fatal("Unexpected statuses", status)
+	}
+	if result.Manifest != "" {
+		t.Fatal("Expected no manifest for a verification")
+	}
+
+	// A single file is verified against its entry in a manifest file
+	os.WriteFile(filepath.Join(root, "SHA256SUMS"),
+		[]byte(expected[0]+" *dist/abc.txt\r\n"+strings.Repeat("0", 64)+"  other.txt\n"), 0644)
+	result = start(&files.HashRequest{Paths: []string{"/data/abc.txt"}, ManifestPath: "/data/SHA256SUMS"})
+	if result.Matched != 1 || result.Files[0].Status != files.HashStatus_matched {
+		t.Fatal("Expected the file to match", result)
+	}
+
+	_, err = hashes.Start(&files.HashRequest{Paths: []string{"/data/tree"}, Manifest: "abc  one\n"})
+	if err == nil {
+		t.Fatal("Expected an invalid manifest to be rejected")
+	}
+	_, err = hashes.Start(&files.HashRequest{Paths: []string{"/data/tree"}, Manifest: expected[0] + "  ../escape\n"})
+	if err == nil {
+		t.Fatal("Expected a name outside the directory to be rejected")
+	}
+	result = start(&files.HashRequest{Paths: []string{"/data/missing", "/"}})
+	if len(result.Files) != 2 || result.Files[0].Error == "" || result.Files[1].Error == "" {
+		t.Fatal("Expected errors for invalid paths", result)
+	}
+
+	// A cancelled calculation stops hashing
+	big, _ := os.Create(filepath.Join(root, "big.bin"))
+	big.Truncate(512 << 20)
+	big.Close()
+	calc, err := hashes.Start(&files.HashRequest{Paths: []string{"/data/big.bin"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes.Cancel(calc.Id())
+	calc.Wait()
+	result = calc.Result()
+	if !result.Cancelled || result.HashedFiles != 0 || result.TotalBytes != 512<<20 {
+		t.Fatal("Expected the calculation to be cancelled", result)
+	}
+	if hashes.Get(calc.Id()) != calc {
+		t.Fatal("Expected the calculation to be kept")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/index"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+func TestIndex(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "src"), 0755)
+	os.WriteFile(filepath.Join(root, "src", "main.go"), []byte("package main\n\nfunc main() {\n\tprintln(\"Hello Layer8\")\n}\n"), 0644)
+	os.WriteFile(filepath.Join(root, "README.md"), []byte("# Hello\nThe NAS file manager\n"), 0644)
+	os.WriteFile(filepath.Join(root, "image.bin"), []byte("hello\x00layer8"), 0644)
+
+	dir := t.TempDir()
+	err = index.Configure(&config.IndexConfig{Dir: dir, MaxFileSize: 1 << 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index.Scan()
+
+	result, err := index.Query(&files.ContentQuery{Query: "hello layer8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 1 || result.Hits[0].File.Name != "main.go" || result.Hits[0].File.Path != "/data/src" {
+		t.Fatal("expected only main.go, the binary file is not indexed", result)
+	}
+	if len(result.Hits[0].Snippets) != 1 || result.Hits[0].Snippets[0].Line != 4 {
+		t.Fatal("expected the matching line as a snippet", result.Hits[0].Snippets)
+	}
+
+	result, _ = index.Query(&files.ContentQuery{Query: "hello", Path: "/data/src"})
+	if result.Total != 1 {
+		t.Fatal("expected the query to be limited to the path", result)
+	}
+
+	// Changed and removed files are updated by the next scan
+	os.Remove(filepath.Join(root, "src", "main.go"))
+	os.WriteFile(filepath.Join(root, "README.md"), []byte("# Hello Layer8\n"), 0644)
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(filepath.Join(root, "README.md"), future, future)
+	index.Scan()
+	result, _ = index.Query(&files.ContentQuery{Query: "layer8"})
+	if result.Total != 1 || result.Hits[0].File.Name != "README.md" {
+		t.Fatal("expected the index to follow the changes", result)
+	}
+	result, _ = index.Query(&files.ContentQuery{Query: "manager"})
+	if result.Total != 0 {
+		t.Fatal("expected the old content to be gone", result)
+	}
+	if _, err = os.Stat(filepath.Join(dir, index.FileName)); err != nil {
+		t.Fatal("expected the index to be persisted", err)
+	}
+	if _, err = index.Query(&files.ContentQuery{Query: "!"}); err == nil {
+		t.Fatal("expected a query without terms to be rejected")
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/jobs"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestJobs(t *testing.T) {
+	done := jobs.Submit(files.ActionType_copy, "done", "alice", func(job *jobs.Job) *files.ActionResponse {
+		job.AddTotals(10, 1)
+		job.AddBytes(10)
+		job.AddFiles(1)
+		return &files.ActionResponse{Msg: "ok"}
+	})
+	done.Wait()
+	snapshot := done.Snapshot()
+	if snapshot.State != files.JobState_completed || snapshot.BytesDone != 10 || snapshot.FilesDone != 1 {
+		t.Fatal("unexpected job state", snapshot)
+	}
+
+	panicked := jobs.Submit(files.ActionType_copy, "panicked", "alice", func(job *jobs.Job) *files.ActionResponse {
+		panic("broken")
+	})
+	panicked.Wait()
+	snapshot = panicked.Snapshot()
+	if snapshot.State != files.JobState_failed || !snapshot.Response.IsError || !strings.Contains(snapshot.Response.Msg, "broken") {
+		t.Fatal("expected a panic to fail the job", snapshot)
+	}
+
+	started := make(chan bool)
+	cancelled := jobs.Submit(files.ActionType_delete, "cancelled", "alice", func(job *jobs.Job) *files.ActionResponse {
+		started <- true
+		<-job.Context().Done()
+		return &files.ActionResponse{Msg: job.Err().Error(), IsError: true}
+	})
+	<-started
+	if jobs.Cancel(cancelled.Id(), "bob") || jobs.Get(cancelled.Id(), "bob") != nil {
+		t.Fatal("expected the job to be hidden from other users")
+	}
+	if !jobs.Cancel(cancelled.Id(), "alice") {
+		t.Fatal("job not found")
+	}
+	cancelled.Wait()
+	if cancelled.Snapshot().State != files.JobState_cancelled {
+		t.Fatal("expected a cancelled job", cancelled.Snapshot())
+	}
+
+	if len(jobs.List("alice")) < 2 {
+		t.Fatal("expected the finished jobs in the history")
+	}
+	for _, job := range jobs.List("bob") {
+		if job.Id == done.Id() || job.Id == cancelled.Id() {
+			t.Fatal("expected the history to be scoped to the user")
+		}
+	}
+
+	// Actions posted over http run as jobs of the authenticated user
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(root, "a.txt"), []byte("a"), 0644)
+	body := `{"action":"copy","async":true,"source":{"path":"/data","name":"a.txt"},"target":{"path":"/data","name":"b.txt"}}`
+	w := httptest.NewRecorder()
+	actions.ActionHandler(w, httptest.NewRequest("POST", "/files/actions", strings.NewReader(body)), "alice", nil)
+	resp := &files.ActionResponse{}
+	if err := protojson.Unmarshal(w.Body.Bytes(), resp); err != nil || resp.JobId == "" {
+		t.Fatal("expected a job id", w.Code, w.Body.String())
+	}
+	job := jobs.Get(resp.JobId, "alice")
+	if job == nil || jobs.Get(resp.JobId, "") != nil {
+		t.Fatal("expected the job to belong to the user")
+	}
+	job.Wait()
+	w = httptest.NewRecorder()
+	jobs.Handler(w, httptest.NewRequest("POST", "/files/jobs", strings.NewReader(`{"id":"`+resp.JobId+`"}`)), "alice", nil)
+	list := &files.JobList{}
+	protojson.Unmarshal(w.Body.Bytes(), list)
+	if len(list.Jobs) != 1 || list.Jobs[0].State != files.JobState_completed {
+		t.Fatal("expected the completed job", w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	jobs.Handler(w, httptest.NewRequest("POST", "/files/jobs", strings.NewReader(`{"id":"`+resp.JobId+`"}`)), "bob", nil)
+	if w.Code != 404 {
+		t.Fatal("expected the job to be hidden from other users", w.Code)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+func TestLinks(t *testing.T) {
+	root := t.TempDir()
+	other := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}, {Name: "other", Root: other}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "sets", "v1"), 0755)
+	os.MkdirAll(filepath.Join(root, "links"), 0755)
+	os.WriteFile(filepath.Join(root, "sets", "v1", "data.csv"), []byte("a,b"), 0644)
+
+	resp := postAction(t, &files.Action{Action: files.ActionType_symlink, Source: &files.File{Path: "/data/sets", Name: "v1"},
+		Target: &files.File{Path: "/data/links", Name: "latest"}})
+	if resp.IsError {
+		t.Fatal(resp.Msg)
+	}
+	target, err := os.Readlink(filepath.Join(root, "links", "latest"))
+	if err != nil || target != "../sets/v1" {
+		t.Fatal("unexpected link target", target, err)
+	}
+	if _, err = os.Stat(filepath.Join(root, "links", "latest", "data.csv")); err != nil {
+		t.Fatal("expected the link to resolve", err)
+	}
+	resp = postAction(t, &files.Action{Action: files.ActionType_symlink, Source: &files.File{Path: "/data/sets", Name: "v1"},
+		Target: &files.File{Path: "/data/links", Name: "latest"}})
+	if !resp.IsError {
+		t.Fatal("expected an existing link to conflict")
+	}
+	resp = postAction(t, &files.Action{Action: files.ActionType_symlink, Source: &files.File{Path: "/data/sets", Name: "v1"},
+		Target: &files.File{Path: "/other", Name: "v1"}})
+	if !resp.IsError {
+		t.Fatal("expected a link across shares to be rejected")
+	}
+	resp = postAction(t, &files.Action{Action: files.ActionType_symlink, Source: &files.File{Path: "/data", Name: "../.."},
+		Target: &files.File{Path: "/data/links", Name: "up"}})
+	if !resp.IsError {
+		t.Fatal("expected a link escaping the share to be rejected")
+	}
+	os.MkdirAll(filepath.Join(root, "links", "v2", "keep"), 0755)
+	for _, policy := range []files.ConflictPolicy{files.ConflictPolicy_overwrite, files.ConflictPolicy_overwriteIfNewer} {
+		resp = postAction(t, &files.Action{Action: files.ActionType_symlink, ConflictPolicy: policy,
+			Source: &files.File{Path: "/data/sets", Name: "v1"}, Target: &files.File{Path: "/data/links", Name: "v2"}})
+		if !resp.IsError || len(resp.Conflicts) != 1 || resp.Conflicts[0].Resolution != "failed" {
+			t.Fatal("expected an existing directory not to be replaced by a link", policy, resp)
+		}
+		if _, err = os.Stat(filepath.Join(root, "links", "v2", "keep")); err != nil {
+			t.Fatal("expected the directory to be kept", err)
+		}
+	}
+	resp = postAction(t, &files.Action{Action: files.ActionType_symlink, ConflictPolicy: files.ConflictPolicy_keepBoth,
+		Source: &files.File{Path: "/data/sets", Name: "v1"}, Target: &files.File{Path: "/data/links", Name: "v2"}})
+	if resp.IsError || len(resp.Conflicts) != 1 {
+		t.Fatal("expected the link to be kept beside the directory", resp)
+	}
+
+	resp = postAction(t, &files.Action{Action: files.ActionType_hardlink, Source: &files.File{Path: "/data/sets/v1", Name: "data.csv"},
+		Target: &files.File{Path: "/data/links", Name: "data.csv"}})
+	if resp.IsError {
+		t.Fatal(resp.Msg)
+	}
+	a, _ := os.Stat(filepath.Join(root, "sets", "v1", "data.csv"))
+	b, _ := os.Stat(filepath.Join(root, "links", "data.csv"))
+	if !os.SameFile(a, b) {
+		t.Fatal("expected a hard link")
+	}
+	resp = postAction(t, &files.Action{Action: files.ActionType_hardlink, Source: &files.File{Path: "/data/sets", Name: "v1"},
+		Target: &files.File{Path: "/data/links", Name: "dir"}})
+	if !resp.IsError {
+		t.Fatal("expected a hard link to a directory to be rejected")
+	}
+}
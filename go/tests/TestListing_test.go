@@ -0,0 +1,74 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	files2 "github.com/saichler/l8nasfile/go/nas/files"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+)
+
+func TestListing(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Mkdir(filepath.Join(root, "zdir"), 0755)
+	os.WriteFile(filepath.Join(root, "a.txt"), make([]byte, 300), 0644)
+	os.WriteFile(filepath.Join(root, "B.log"), make([]byte, 100), 0644)
+	os.WriteFile(filepath.Join(root, "c.txt"), make([]byte, 200), 0644)
+	os.WriteFile(filepath.Join(root, ".hidden"), make([]byte, 10), 0644)
+
+	service := &files2.FileService{}
+	listing := func(options *files.ListOptions) *files.FileList {
+		req := &files.File{Path: "/", Name: "data", IsDirectory: true, Options: options}
+		list, ok := service.Post(object.New(nil, req), nil).Element().(*files.FileList)
+		if !ok {
+			t.Fatal("expected a file list")
+		}
+		return list
+	}
+	names := func(list *files.FileList) string {
+		result := ""
+		for _, f := range list.Fiels {
+			result += f.Name + ","
+		}
+		return result
+	}
+
+	list := listing(nil)
+	if list.Total != 5 || names(list) != "zdir,.hidden,a.txt,B.log,c.txt," {
+		t.Fatal("unexpected default listing", names(list))
+	}
+	list = listing(&files.ListOptions{Sort: files.SortKey_bySize, Descending: true, HideHidden: true, Limit: 2})
+	if list.Total != 4 || names(list) != "zdir,a.txt," || list.NextCursor == "" {
+		t.Fatal("unexpected first page", names(list), list.Total)
+	}
+	list = listing(&files.ListOptions{Sort: files.SortKey_bySize, Descending: true, HideHidden: true, Limit: 2, Cursor: list.NextCursor})
+	if names(list) != "c.txt,B.log," || list.Offset != 2 || list.NextCursor != "" {
+		t.Fatal("unexpected second page", names(list), list.Offset)
+	}
+	list = listing(&files.ListOptions{Filter: "*.TXT", Offset: 1})
+	if list.Total != 2 || names(list) != "c.txt," {
+		t.Fatal("unexpected filtered listing", names(list))
+	}
+}
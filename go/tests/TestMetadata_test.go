@@ -0,0 +1,81 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	files2 "github.com/saichler/l8nasfile/go/nas/files"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+)
+
+func TestMetadata(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Mkdir(filepath.Join(root, "dir"), 0755)
+	os.WriteFile(filepath.Join(root, "file.txt"), []byte("hello"), 0640)
+	os.Chmod(filepath.Join(root, "file.txt"), 0640)
+	os.Symlink("dir", filepath.Join(root, "relative"))
+	os.Symlink(filepath.Join(root, "file.txt"), filepath.Join(root, "absolute"))
+	os.Symlink("/etc", filepath.Join(root, "outside"))
+	os.WriteFile(filepath.Join(filepath.Dir(root), "secret"), []byte("secret"), 0644)
+	os.Symlink("../secret", filepath.Join(root, "escaping"))
+	os.Symlink("missing", filepath.Join(root, "dangling"))
+
+	service := &files2.FileService{}
+	req := &files.File{Path: "/", Name: "data", IsDirectory: true}
+	list, ok := service.Post(object.New(nil, req), nil).Element().(*files.FileList)
+	if !ok {
+		t.Fatal("expected a file list")
+	}
+	byName := make(map[string]*files.File)
+	for _, f := range list.Fiels {
+		byName[f.Name] = f
+	}
+
+	file := byName["file.txt"]
+	if file.Permissions != "-rw-r-----" || file.Mode&0777 != 0640 || file.Links != 1 || file.Inode == 0 ||
+		file.Owner == "" || file.Group == "" || file.Changed == 0 || file.IsSymlink {
+		t.Fatal("unexpected file metadata", file)
+	}
+	if byName["dir"].Permissions[0] != 'd' || !byName["dir"].IsDirectory {
+		t.Fatal("unexpected directory metadata", byName["dir"])
+	}
+	relative := byName["relative"]
+	if !relative.IsSymlink || relative.IsDirectory || relative.LinkTarget != "dir" || !relative.LinkIsDirectory {
+		t.Fatal("unexpected relative link", relative)
+	}
+	absolute := byName["absolute"]
+	if !absolute.IsSymlink || absolute.LinkTarget != "/data/file.txt" || absolute.LinkIsDirectory {
+		t.Fatal("unexpected absolute link", absolute)
+	}
+	outside := byName["outside"]
+	if !outside.IsSymlink || outside.LinkTarget != "" || outside.LinkIsDirectory {
+		t.Fatal("unexpected outside link", outside)
+	}
+	for _, name := range []string{"escaping", "dangling"} {
+		if !byName[name].IsSymlink || byName[name].LinkTarget != "" || byName[name].LinkIsDirectory {
+			t.Fatal("expected the relative target to be hidden", byName[name])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	files2 "github.com/saichler/l8nasfile/go/nas/files"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"github.com/saichler/l8srlz/go/serialize/object"
+)
+
+func TestMime(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	os.WriteFile(filepath.Join(root, "picture"), png, 0644)
+	os.WriteFile(filepath.Join(root, "notes.txt"), []byte("hello"), 0644)
+	os.WriteFile(filepath.Join(root, "page.html"), []byte("<html></html>"), 0644)
+
+	types := func(sniff bool) map[string]string {
+		req := &files.File{Path: "/", Name: "data", IsDirectory: true, Options: &files.ListOptions{Sniff: sniff}}
+		list, ok := (&files2.FileService{}).Post(object.New(nil, req), nil).Element().(*files.FileList)
+		if !ok {
+			t.Fatal("expected a file list")
+		}
+		result := make(map[string]string)
+		for _, f := range list.Fiels {
+			result[f.Name] = f.Type
+		}
+		return result
+	}
+	list := types(false)
+	if list["picture"] != "" || list["notes.txt"] != "text/plain; charset=utf-8" {
+		t.Fatal("unexpected types without sniffing", list)
+	}
+	list = types(true)
+	if list["picture"] != "image/png" {
+		t.Fatal("unexpected sniffed type", list)
+	}
+
+	download := func(query string) string {
+		w := httptest.NewRecorder()
+		actions.DownloadHandler(w, httptest.NewRequest("GET", "/files/download?"+query, nil), nil)
+		if w.Code != 200 {
+			t.Fatal("unexpected download status", w.Code)
+		}
+		return w.Header().Get("Content-Type")
+	}
+	if ct := download("path=/data/picture"); ct != "image/png" {
+		t.Fatal("unexpected content type", ct)
+	}
+	if ct := download("path=/data/page.html"); ct != "text/html; charset=utf-8" {
+		t.Fatal("unexpected content type", ct)
+	}
+	if ct := download("path=/data/page.html&disposition=inline"); ct != "text/plain; charset=utf-8" {
+		t.Fatal("html must not be served inline", ct)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+func TestPermissions(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "dir", "sub"), 0755)
+	os.WriteFile(filepath.Join(root, "dir", "a.sh"), []byte("echo"), 0644)
+	os.WriteFile(filepath.Join(root, "dir", "sub", "b.txt"), []byte("b"), 0644)
+	os.Symlink("/etc/passwd", filepath.Join(root, "dir", "link"))
+	actions.ConfigureAuthorization(&config.AuthorizationConfig{Chmod: []string{"admin"}, Chown: []string{"*"}})
+	defer actions.ConfigureAuthorization(config.Default().Authorization)
+
+	run := func(ac *files.Action, user string) *files.ActionResponse {
+		return actions.Run(ac, user)
+	}
+	perm := func(path string) os.FileMode {
+		info, err := os.Lstat(filepath.Join(root, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info.Mode() & (os.ModePerm | os.ModeSetgid)
+	}
+	source := &files.File{Path: "/data", Name: "dir"}
+
+	resp := run(&files.Action{Action: files.ActionType_chmod, Source: source, Mode: "700"}, "guest")
+	if !resp.IsError || perm("dir") != 0755 {
+		t.Fatal("expected an unauthorized user to be rejected", resp.Msg)
+	}
+	// The service callers are not identified, only "*" allows them
+	resp = postAction(t, &files.Action{Action: files.ActionType_chmod, Source: source, Mode: "700"})
+	if !resp.IsError || perm("dir") != 0755 {
+		t.Fatal("expected an anonymous user to be rejected", resp.Msg)
+	}
+	resp = run(&files.Action{Action: files.ActionType_chmod, Source: source, Mode: "a=rX,u+w,g+s", Recursive: true}, "admin")
+	if resp.IsError || len(resp.Results) != 5 {
+		t.Fatal("unexpected chmod response", resp.Msg, resp.Results)
+	}
+	if perm("dir") != 0755|os.ModeSetgid || perm("dir/a.sh") != 0644|os.ModeSetgid || perm("dir/sub") != 0755|os.ModeSetgid {
+		t.Fatal("unexpected symbolic modes", perm("dir"), perm("dir/a.sh"), perm("dir/sub"))
+	}
+	resp = run(&files.Action{Action: files.ActionType_chmod, Source: &files.File{Path: "/data/dir", Name: "a.sh"}, Mode: "0750"}, "admin")
+	if resp.IsError || perm("dir/a.sh") != 0750 || len(resp.Results) != 1 {
+		t.Fatal("unexpected octal chmod", resp.Msg, perm("dir/a.sh"))
+	}
+	resp = run(&files.Action{Action: files.ActionType_chmod, Source: source, Mode: "u+q"}, "admin")
+	if !resp.IsError {
+		t.Fatal("expected an invalid mode to be rejected")
+	}
+
+	owner := strconv.Itoa(os.Getuid()) + ":" + strconv.Itoa(os.Getgid())
+	resp = postAction(t, &files.Action{Action: files.ActionType_chown, Sources: []*files.File{source}, Owner: owner, Recursive: true})
+	if resp.IsError || len(resp.Items) != 1 || len(resp.Results) != 5 {
+		t.Fatal("unexpected chown response", resp.Msg, resp.Results)
+	}
+	resp = postAction(t, &files.Action{Action: files.ActionType_chown, Source: source, Owner: "no-such-user-here"})
+	if !resp.IsError {
+		t.Fatal("expected an unknown user to be rejected")
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/jobs"
+	"github.com/saichler/l8nasfile/go/nas/registry"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+type registryTask struct {
+	cancelled bool
+}
+
+func (this *registryTask) Ended() time.Time {
+	return time.Time{}
+}
+
+func (this *registryTask) Cancel() {
+	this.cancelled = true
+}
+
+func TestRegistry(t *testing.T) {
+	tasks := registry.New(time.Minute)
+	task := &registryTask{}
+	id := registry.NewId()
+	if id == registry.NewId() {
+		t.Fatal("expected unique ids")
+	}
+	tasks.Add(id, task)
+	if tasks.Get(id) != task || tasks.Get("missing") != nil || len(tasks.List()) != 1 {
+		t.Fatal("unexpected registry content")
+	}
+	if !tasks.Cancel(id) || !task.cancelled || tasks.Cancel("missing") {
+		t.Fatal("expected the task to be cancelled")
+	}
+}
+
+func TestRegistryEvents(t *testing.T) {
+	release := make(chan struct{})
+	job := jobs.Submit(files.ActionType_copy, "events", "alice", func(job *jobs.Job) *files.ActionResponse {
+		<-release
+		return &files.ActionResponse{Msg: "copied"}
+	})
+	w := httptest.NewRecorder()
+	jobs.EventsHandler(w, httptest.NewRequest("GET", "/files/jobs/events?id="+job.Id(), nil), "bob", nil)
+	if w.Code != 404 {
+		t.Fatal("expected the job of another user not to be streamed", w.Code)
+	}
+	w = httptest.NewRecorder()
+	streamed := make(chan struct{})
+	go func() {
+		jobs.EventsHandler(w, httptest.NewRequest("GET", "/files/jobs/events?id="+job.Id(), nil), "alice", nil)
+		close(streamed)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	<-streamed
+	events := strings.Count(w.Body.String(), "event: job\n")
+	if events < 2 || !strings.Contains(w.Body.String(), `"state":"completed"`) {
+		t.Fatal("expected the progress and the end of the job", w.Body.String())
+	}
+}
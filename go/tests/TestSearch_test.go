@@ -0,0 +1,104 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/search"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+func TestSearch(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "docs", "reports"), 0755)
+	os.WriteFile(filepath.Join(root, "docs", "Report-2024.txt"), []byte("small"), 0644)
+	os.WriteFile(filepath.Join(root, "docs", "reports", "q1.pdf"), make([]byte, 2048), 0644)
+	os.WriteFile(filepath.Join(root, "notes.md"), []byte("notes"), 0644)
+
+	find := func(req *files.SearchRequest) *files.SearchResult {
+		s, err := search.Start(req, "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Wait()
+		return s.Result(0)
+	}
+
+	result := find(&files.SearchRequest{Path: "/data", Name: "report"})
+	if result.Total != 2 || !result.Done {
+		t.Fatal("expected the file and the directory named report", result)
+	}
+	result = find(&files.SearchRequest{Path: "/data", Name: "report", EntryType: files.SearchEntryType_fileEntry})
+	if result.Total != 1 || result.Files[0].Path != "/data/docs" || result.Files[0].Name != "Report-2024.txt" {
+		t.Fatal("expected only the report file", result)
+	}
+	result = find(&files.SearchRequest{Path: "/", Glob: "*.pdf", MinSize: 1024})
+	if result.Total != 1 || result.Files[0].Path != "/data/docs/reports" {
+		t.Fatal("expected the pdf file", result)
+	}
+	result = find(&files.SearchRequest{Path: "/data", Regex: `\.(md|txt)$`, Limit: 1})
+	if result.Total != 1 || !result.Truncated {
+		t.Fatal("expected a truncated result", result)
+	}
+	if _, err = search.Start(&files.SearchRequest{Path: "/data", Regex: "("}, "alice"); err == nil {
+		t.Fatal("expected an invalid regex to be rejected")
+	}
+}
+
+func TestSearchOwner(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(root, "found.txt"), []byte("x"), 0644)
+	s, err := search.Start(&files.SearchRequest{Path: "/data", Name: "found"}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if search.Get(s.Id(), "bob") != nil || search.Cancel(s.Id(), "bob") {
+		t.Fatal("expected the search to be hidden from other users")
+	}
+	if _, err = search.Request(&files.SearchRequest{Id: s.Id()}, "bob"); err == nil {
+		t.Fatal("expected the results to be hidden from other users")
+	}
+	w := httptest.NewRecorder()
+	search.EventsHandler(w, httptest.NewRequest("GET", "/files/search/events?id="+s.Id(), nil), "bob", nil)
+	if w.Code != 404 {
+		t.Fatal("expected the search of another user not to be streamed", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	search.EventsHandler(w, httptest.NewRequest("GET", "/files/search/events?id="+s.Id(), nil), "alice", nil)
+	body := w.Body.String()
+	if !strings.HasPrefix(body, "event: results\n") || !strings.Contains(body, "found.txt") || !strings.Contains(body, `"done":true`) {
+		t.Fatal("expected the results of the search", body)
+	}
+	result, err := search.Request(&files.SearchRequest{Id: s.Id(), Wait: true}, "alice")
+	if err != nil || result.Total != 1 {
+		t.Fatal("unexpected search result", result, err)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+)
+
+func TestShares(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "data", "sub"), 0755)
+	os.Symlink(outside, filepath.Join(root, "data", "escape"))
+	os.Symlink(filepath.Join(root, "data", "sub"), filepath.Join(root, "data", "inside"))
+	os.Symlink(filepath.Join(outside, "missing"), filepath.Join(root, "data", "dangling"))
+
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: filepath.Join(root, "data")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	realRoot := shares.Get("data").RealRoot()
+	allowed := map[string]string{
+		"/data":                realRoot,
+		"/data/sub":            filepath.Join(realRoot, "sub"),
+		"data/sub/new/file":    filepath.Join(realRoot, "sub", "new", "file"),
+		"/data/inside/x":       filepath.Join(realRoot, "sub", "x"),
+		"/data/sub/../sub/./y": filepath.Join(realRoot, "sub", "y"),
+	}
+	for virtualPath, expected := range allowed {
+		realPath, err := shares.Resolve(virtualPath)
+		if err != nil {
+			t.Fatal(virtualPath, err)
+		}
+		if realPath != expected {
+			t.Fatal(virtualPath, "expected", expected, "got", realPath)
+		}
+	}
+
+	denied := []string{"/", "/etc/passwd", "/data/../../etc", "/data/escape", "/data/escape/x", "/data/dangling"}
+	for _, virtualPath := range denied {
+		realPath, err := shares.Resolve(virtualPath)
+		if err == nil {
+			t.Fatal(virtualPath, "should be denied, resolved to", realPath)
+		}
+	}
+
+	virtualPath, err := shares.Virtual(filepath.Join(realRoot, "sub", "z"))
+	if err != nil || virtualPath != "/data/sub/z" {
+		t.Fatal("unexpected virtual path", virtualPath, err)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/sizes"
+)
+
+func TestSizes(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "a", "b", "c"), 0755)
+	os.WriteFile(filepath.Join(root, "a", "one"), make([]byte, 100), 0644)
+	os.WriteFile(filepath.Join(root, "a", "b", "two"), make([]byte, 200), 0644)
+	os.WriteFile(filepath.Join(root, "a", "b", "c", "three"), make([]byte, 300), 0644)
+	err = os.Link(filepath.Join(root, "a", "b", "two"), filepath.Join(root, "a", "b", "c", "two"))
+	if err != nil {
+		t.Skip("hard links are not supported", err)
+	}
+
+	calc := sizes.Start([]string{"/data/a", "/data/a/one", "/data/missing"})
+	calc.Wait()
+	result := calc.Result()
+	a := result.Sizes[0]
+	if a.Size != 600 || a.Files != 3 || a.Directories != 2 || a.Error != "" {
+		t.Fatal("expected the hard linked file to be counted once", a)
+	}
+	if result.Sizes[1].Size != 100 || result.Sizes[1].Files != 1 {
+		t.Fatal("unexpected size of a file", result.Sizes[1])
+	}
+	if result.Sizes[2].Error == "" {
+		t.Fatal("expected an error for a missing path")
+	}
+
+	// The cached directories are read again once they change
+	os.WriteFile(filepath.Join(root, "a", "b", "c", "four"), make([]byte, 400), 0644)
+	calc = sizes.Start([]string{"/data/a"})
+	calc.Wait()
+	if calc.Result().Sizes[0].Size != 1000 {
+		t.Fatal("expected the new file to be counted", calc.Result().Sizes[0])
+	}
+
+	// Appending to a file does not change its directory, the file is stat-ed again
+	f, err := os.OpenFile(filepath.Join(root, "a", "one"), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write(make([]byte, 5000))
+	f.Close()
+	calc = sizes.Start([]string{"/data/a"})
+	calc.Wait()
+	if calc.Result().Sizes[0].Size != 6000 {
+		t.Fatal("expected the appended file to be counted", calc.Result().Sizes[0])
+	}
+}
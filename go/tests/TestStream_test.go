@@ -0,0 +1,103 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	files2 "github.com/saichler/l8nasfile/go/nas/files"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestStream(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		os.WriteFile(filepath.Join(root, fmt.Sprintf("f%d.txt", i)), []byte("x"), 0644)
+	}
+	os.WriteFile(filepath.Join(root, "g.log"), []byte("x"), 0644)
+	os.WriteFile(filepath.Join(root, ".hidden.txt"), []byte("x"), 0644)
+	os.Mkdir(filepath.Join(root, trash.Dir), 0755)
+
+	// stream returns the names of the "files" events and the "done" event
+	stream := func(query string) ([]string, *files.FileList) {
+		w := httptest.NewRecorder()
+		files2.StreamHandler(w, httptest.NewRequest("GET", "/files/stream?path=/data&"+query, nil), nil)
+		if w.Code != http.StatusOK {
+			t.Fatal("unexpected status", w.Code, w.Body.String())
+		}
+		names := make([]string, 0)
+		var done *files.FileList
+		for _, event := range strings.Split(strings.TrimSpace(w.Body.String()), "\n\n") {
+			lines := strings.SplitN(event, "\n", 2)
+			list := &files.FileList{}
+			err := protojson.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), list)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch lines[0] {
+			case "event: files":
+				if done != nil || len(list.Fiels) > 3 || int(list.Offset) != len(names) {
+					t.Fatal("unexpected batch", list)
+				}
+				for _, f := range list.Fiels {
+					names = append(names, f.Name)
+				}
+			case "event: done":
+				done = list
+			default:
+				t.Fatal("unexpected event", event)
+			}
+		}
+		if done == nil || int(done.Total) != len(names) {
+			t.Fatal("expected a done event with the total", done, names)
+		}
+		sort.Strings(names)
+		return names, done
+	}
+
+	names, _ := stream("batch=3")
+	if len(names) != 12 || names[0] != ".hidden.txt" {
+		t.Fatal("expected all the entries but the trash", names)
+	}
+	names, _ = stream("batch=3&hideHidden=true&filter=*.TXT")
+	if strings.Join(names, ",") != "f0.txt,f1.txt,f2.txt,f3.txt,f4.txt,f5.txt,f6.txt,f7.txt,f8.txt,f9.txt" {
+		t.Fatal("unexpected glob filtered entries", names)
+	}
+	names, _ = stream("batch=3&filter=F1")
+	if strings.Join(names, ",") != "f1.txt" {
+		t.Fatal("unexpected substring filtered entries", names)
+	}
+
+	w := httptest.NewRecorder()
+	files2.StreamHandler(w, httptest.NewRequest("GET", "/files/stream?path=/data&filter=[", nil), nil)
+	if w.Code != http.StatusBadRequest {
+		t.Fatal("expected an invalid filter to be rejected", w.Code)
+	}
+}
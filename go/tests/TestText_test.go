@@ -0,0 +1,156 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestText(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "é" is 2 bytes and "€" is 3 bytes in UTF-8
+	os.WriteFile(filepath.Join(root, "utf8.txt"), []byte("aé€b"), 0640)
+	os.WriteFile(filepath.Join(root, "latin1.txt"), []byte("caf\xe9"), 0644)
+	os.WriteFile(filepath.Join(root, "utf16.txt"), []byte("\xff\xfeh\x00i\x00"), 0644)
+	os.WriteFile(filepath.Join(root, "binary.bin"), []byte("a\x00b"), 0644)
+
+	get := func(query string) (int, *files.TextContent) {
+		w := httptest.NewRecorder()
+		actions.TextHandler(w, httptest.NewRequest("GET", "/files/text?"+query, nil), nil)
+		text := &files.TextContent{}
+		if w.Code == 200 {
+			if err := protojson.Unmarshal(w.Body.Bytes(), text); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return w.Code, text
+	}
+	put := func(path, content string, headers map[string]string) (int, string) {
+		r := httptest.NewRequest("PUT", "/files/text?path="+path, strings.NewReader(content))
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		actions.TextHandler(w, r, nil)
+		return w.Code, w.Header().Get("ETag")
+	}
+
+	code, text := get("path=/data/utf8.txt")
+	if code != 200 || text.Content != "aé€b" || text.Encoding != "utf-8" || text.More || text.Etag == "" {
+		t.Fatal("Unexpected text", code, text)
+	}
+	// A window cutting "é" is extended to its end
+	_, text = get("path=/data/utf8.txt&offset=0&length=2")
+	if text.Content != "aé" || text.Length != 3 || !text.More {
+		t.Fatal("Expected window to end on a character boundary", text)
+	}
+	// A window starting inside "é" skips to the next character
+	_, text = get("path=/data/utf8.txt&offset=2&length=3")
+	if text.Content != "€" || text.Offset != 3 {
+		t.Fatal("Expected window to start on a character boundary", text)
+	}
+	_, text = get("path=/data/latin1.txt")
+	if text.Content != "café" || text.Encoding != "iso-8859-1" {
+		t.Fatal("Expected latin1 text", text)
+	}
+	_, text = get("path=/data/utf16.txt")
+	if text.Content != "hi" || text.Encoding != "utf-16le" || text.Offset != 2 {
+		t.Fatal("Expected utf-16 text", text)
+	}
+	if code, _ = get("path=/data/binary.bin"); code != 415 {
+		t.Fatal("Expected binary file to be rejected", code)
+	}
+	if code, _ = get("path=/other/utf8.txt"); code != 403 {
+		t.Fatal("Expected access denied", code)
+	}
+
+	_, text = get("path=/data/utf8.txt")
+	if code, _ = put("/data/utf8.txt", "new", nil); code != 428 {
+		t.Fatal("Expected save without precondition to be rejected", code)
+	}
+	code, current := put("/data/utf8.txt", "new", map[string]string{"If-Match": `"stale"`})
+	if code != 412 || current != text.Etag {
+		t.Fatal("Expected stale save to fail with the current etag", code, current)
+	}
+	if code, _ = put("/data/utf8.txt", "new", map[string]string{"If-Match": text.Etag}); code != 200 {
+		t.Fatal("Expected save to succeed", code)
+	}
+	data, _ := os.ReadFile(filepath.Join(root, "utf8.txt"))
+	info, _ := os.Stat(filepath.Join(root, "utf8.txt"))
+	if string(data) != "new" || info.Mode().Perm() != 0640 {
+		t.Fatal("Expected content replaced and mode kept", string(data), info.Mode())
+	}
+	// The old etag no longer matches
+	if code, _ = put("/data/utf8.txt", "newer", map[string]string{"If-Match": text.Etag}); code != 412 {
+		t.Fatal("Expected save with the old etag to fail", code)
+	}
+
+	if code, _ = put("/data/created.txt", "hello", map[string]string{"If-None-Match": "*"}); code != 200 {
+		t.Fatal("Expected file to be created", code)
+	}
+	if code, _ = put("/data/created.txt", "again", map[string]string{"If-None-Match": "*"}); code != 412 {
+		t.Fatal("Expected existing file not to be overwritten", code)
+	}
+	if code, _ = put("/data/missing.txt", "hello", map[string]string{"If-Match": "*"}); code != 412 {
+		t.Fatal("Expected save of a missing file to fail", code)
+	}
+	r := httptest.NewRequest("PUT", "/files/text?path=/data/latin1.txt&encoding=iso-8859-1", strings.NewReader("déjà"))
+	r.Header.Set("If-Match", "*")
+	w := httptest.NewRecorder()
+	actions.TextHandler(w, r, nil)
+	data, _ = os.ReadFile(filepath.Join(root, "latin1.txt"))
+	if w.Code != 200 || string(data) != "d\xe9j\xe0" {
+		t.Fatal("Expected latin1 content", w.Code, data)
+	}
+
+	// A link is saved through and stays a link
+	os.Symlink("created.txt", filepath.Join(root, "link.txt"))
+	if code, _ = put("/data/link.txt", "linked", map[string]string{"If-Match": "*"}); code != 200 {
+		t.Fatal("Expected save through the link to succeed", code)
+	}
+	data, _ = os.ReadFile(filepath.Join(root, "created.txt"))
+	if target, err := os.Readlink(filepath.Join(root, "link.txt")); err != nil || target != "created.txt" || string(data) != "linked" {
+		t.Fatal("Expected the link to be kept and its target saved", target, err, string(data))
+	}
+	os.Symlink("nowhere.txt", filepath.Join(root, "dangling.txt"))
+	if code, _ = put("/data/dangling.txt", "hello", map[string]string{"If-None-Match": "*"}); code != 412 {
+		t.Fatal("Expected a dangling link not to be replaced", code)
+	}
+	if os.Getuid() == 0 {
+		os.Chown(filepath.Join(root, "created.txt"), 1234, 1234)
+		if code, _ = put("/data/created.txt", "owned", map[string]string{"If-Match": "*"}); code != 200 {
+			t.Fatal("Expected save to succeed", code)
+		}
+		info, _ = os.Stat(filepath.Join(root, "created.txt"))
+		if stat := info.Sys().(*syscall.Stat_t); stat.Uid != 1234 || stat.Gid != 1234 {
+			t.Fatal("Expected the owner to be kept", stat.Uid, stat.Gid)
+		}
+	}
+}
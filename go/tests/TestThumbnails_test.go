@@ -0,0 +1,146 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/thumbnails"
+)
+
+func TestThumbnails(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := t.TempDir()
+	err = thumbnails.Configure(&config.ThumbnailConfig{Enabled: true, Dir: cacheDir, Workers: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Left half red, right half blue
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 200 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	pngFile, _ := os.Create(filepath.Join(root, "wide.png"))
+	png.Encode(pngFile, img)
+	pngFile.Close()
+	buff := &bytes.Buffer{}
+	jpeg.Encode(buff, img, nil)
+	// An EXIF segment with orientation 6, rotate 90 degrees clockwise
+	exif := []byte("Exif\x00\x00II*\x00\x08\x00\x00\x00\x01\x00\x12\x01\x03\x00\x01\x00\x00\x00\x06\x00\x00\x00\x00\x00\x00\x00")
+	segment := append([]byte{0xff, 0xe1, 0, byte(len(exif) + 2)}, exif...)
+	data := append(append([]byte{0xff, 0xd8}, segment...), buff.Bytes()[2:]...)
+	os.WriteFile(filepath.Join(root, "rotated.jpg"), data, 0644)
+	os.WriteFile(filepath.Join(root, "broken.gif"), []byte("not an image"), 0644)
+
+	decode := func(path string) image.Image {
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+		result, _, err := image.Decode(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	path, contentType, err := thumbnails.Get(filepath.Join(root, "wide.png"), 100)
+	if err != nil || contentType != "image/png" {
+		t.Fatal(err, contentType)
+	}
+	if b := decode(path).Bounds(); b.Dx() != 128 || b.Dy() != 64 {
+		t.Fatal("expected the size to be snapped up", b)
+	}
+	if snapped, _, _ := thumbnails.Get(filepath.Join(root, "wide.png"), 120); snapped != path {
+		t.Fatal("expected the sizes of a bucket to share the thumbnail", snapped, path)
+	}
+	path, contentType, err = thumbnails.Get(filepath.Join(root, "rotated.jpg"), 100)
+	if err != nil || contentType != "image/jpeg" {
+		t.Fatal(err, contentType)
+	}
+	rotated := decode(path)
+	if b := rotated.Bounds(); b.Dx() != 64 || b.Dy() != 128 {
+		t.Fatal("expected the orientation to be applied", b)
+	}
+	if r, _, b, _ := rotated.At(32, 10).RGBA(); r < b {
+		t.Fatal("expected red on top")
+	}
+	if r, _, b, _ := rotated.At(32, 118).RGBA(); r > b {
+		t.Fatal("expected blue at the bottom")
+	}
+	if _, _, err = thumbnails.Get(filepath.Join(root, "broken.gif"), 100); err == nil {
+		t.Fatal("expected a broken image to fail")
+	}
+	// A lossless 1x1 WebP image
+	webp, _ := base64.StdEncoding.DecodeString("UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA==")
+	os.WriteFile(filepath.Join(root, "dot.webp"), webp, 0644)
+	path, contentType, err = thumbnails.Get(filepath.Join(root, "dot.webp"), 64)
+	if err != nil || contentType != "image/png" {
+		t.Fatal("expected a WebP thumbnail", err, contentType)
+	}
+	if b := decode(path).Bounds(); b.Dx() != 1 || b.Dy() != 1 {
+		t.Fatal("unexpected WebP thumbnail size", b)
+	}
+
+	w := httptest.NewRecorder()
+	thumbnails.Handler(w, httptest.NewRequest("GET", "/files/thumbnail?path=/data/wide.png&size=64", nil), nil)
+	if w.Code != 200 || w.Header().Get("Content-Type") != "image/png" || w.Header().Get("ETag") == "" {
+		t.Fatal("unexpected thumbnail response", w.Code)
+	}
+	w = httptest.NewRecorder()
+	thumbnails.Handler(w, httptest.NewRequest("GET", "/files/thumbnail?path=/data/../../etc/passwd", nil), nil)
+	if w.Code == 200 {
+		t.Fatal("expected a path outside the share to fail")
+	}
+
+	// Background generation of the default size
+	cachedFiles := func() int {
+		matches, _ := filepath.Glob(filepath.Join(cacheDir, "*", "*"))
+		return len(matches)
+	}
+	before := cachedFiles()
+	thumbnails.Prefetch([]string{filepath.Join(root, "wide.png"), filepath.Join(root, "notes.txt")})
+	for i := 0; i < 100 && cachedFiles() == before; i++ {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if cachedFiles() != before+1 {
+		t.Fatal("expected the thumbnail to be generated in the background")
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/trash"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+func TestTrash(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(root, "a.txt"), []byte("hello"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = trash.Add("/data", true, 0, "admin"); err == nil {
+		t.Fatal("expected the share root to be rejected")
+	}
+	item, trashPath, err := trash.Add("/data/a.txt", false, 5, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !trash.IsTrash(trashPath) || trash.IsTrash(filepath.Join(root, "a.txt")) {
+		t.Fatal("unexpected trash path", trashPath)
+	}
+	err = os.Rename(filepath.Join(root, "a.txt"), trashPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	share, found, err := trash.Find(item.Id, "admin")
+	if err != nil || share.Name != "data" || found.Path != "/data/a.txt" || found.User != "admin" {
+		t.Fatal("unexpected trash item", found, err)
+	}
+	if _, _, err = trash.Find(item.Id, "bob"); err == nil {
+		t.Fatal("expected the item of another user not to be found")
+	}
+	if _, _, err = trash.Find("../info", "admin"); err == nil {
+		t.Fatal("expected an invalid id to be rejected")
+	}
+	items, err := trash.List(share)
+	if err != nil || len(items) != 1 {
+		t.Fatal("expected a single trash item", items, err)
+	}
+
+	err = trash.Forget(share, item.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, _ = trash.List(share)
+	if len(items) != 0 {
+		t.Fatal("expected an empty trash", items)
+	}
+}
+
+func TestTrashActions(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	trash.Configure(&config.TrashConfig{Enabled: true})
+	defer trash.Configure(&config.TrashConfig{})
+	os.WriteFile(filepath.Join(root, "a.txt"), []byte("hello"), 0644)
+
+	resp := actions.Run(&files.Action{Action: files.ActionType_delete,
+		Source: &files.File{Path: "/data", Name: "a.txt"}}, "alice")
+	if resp.IsError {
+		t.Fatal("unexpected response", resp)
+	}
+	share := shares.Get("data")
+	items, err := trash.List(share)
+	if err != nil || len(items) != 1 || items[0].User != "alice" {
+		t.Fatal("expected the trash item of the authenticated user", items, err)
+	}
+
+	// Other users neither see, restore nor purge the item
+	source := &files.File{Path: "/", Name: "data"}
+	if resp = actions.Run(&files.Action{Action: files.ActionType_listTrash, Source: source}, "bob"); len(resp.Trash) != 0 {
+		t.Fatal("expected the trash of another user to be hidden", resp.Trash)
+	}
+	resp = actions.Run(&files.Action{Action: files.ActionType_restore, TrashIds: []string{items[0].Id}}, "bob")
+	if !resp.IsError {
+		t.Fatal("expected the item of another user not to be restored", resp)
+	}
+	actions.Run(&files.Action{Action: files.ActionType_purge, Source: source}, "bob")
+	resp = actions.Run(&files.Action{Action: files.ActionType_listTrash, Source: source}, "alice")
+	if len(resp.Trash) != 1 {
+		t.Fatal("expected the item to be kept", resp)
+	}
+	resp = actions.Run(&files.Action{Action: files.ActionType_restore, TrashIds: []string{items[0].Id}}, "alice")
+	if resp.IsError {
+		t.Fatal("unexpected restore", resp)
+	}
+	if _, err = os.Stat(filepath.Join(root, "a.txt")); err != nil {
+		t.Fatal("expected the item to be restored", err)
+	}
+
+	// The trash is not reachable through the virtual paths, even by a link
+	os.Symlink(trash.Dir, filepath.Join(root, "link"))
+	for _, path := range []string{"/data/.trash", "/data/.trash/files", "/data/link"} {
+		if _, err = shares.Resolve(path); err == nil {
+			t.Fatal("expected the trash path to be rejected", path)
+		}
+	}
+	trashed := &files.File{Path: "/data/.trash", Name: "files"}
+	for _, ac := range []*files.Action{
+		{Action: files.ActionType_copy, Source: trashed, Target: &files.File{Path: "/", Name: "data"}},
+		{Action: files.ActionType_delete, Source: trashed, Permanent: true},
+	} {
+		if resp = actions.Run(ac, "alice"); !resp.IsError {
+			t.Fatal("expected the trash action to be rejected", ac.Action)
+		}
+	}
+	if _, err = os.Stat(filepath.Join(root, trash.Dir, "files")); err != nil {
+		t.Fatal("expected the trash to be kept", err)
+	}
+}
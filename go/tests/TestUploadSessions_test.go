@@ -0,0 +1,170 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// sessionRequest sends a request to the upload sessions handler.
+func sessionRequest(sessions *actions.UploadSessions, method, path string, headers map[string]string, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, actions.SessionsEndpoint+path, strings.NewReader(body))
+	for name, value := range headers {
+		r.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	sessions.Handler(w, r, nil)
+	return w
+}
+
+// createSession starts an upload of size bytes of the file name into /data.
+func createSession(t *testing.T, sessions *actions.UploadSessions, name string, size int) string {
+	w := sessionRequest(sessions, http.MethodPost, "?path=/data&name="+name,
+		map[string]string{"Upload-Length": strconv.Itoa(size)}, "")
+	if w.Code != http.StatusCreated {
+		t.Fatal("unexpected create status", w.Code, w.Body.String())
+	}
+	session := &files.UploadSession{}
+	err := protojson.Unmarshal(w.Body.Bytes(), session)
+	if err != nil || session.Id == "" || w.Header().Get("Location") != actions.SessionsEndpoint+"/"+session.Id {
+		t.Fatal("unexpected session", session, err)
+	}
+	return session.Id
+}
+
+func offsetOf(t *testing.T, sessions *actions.UploadSessions, id string) string {
+	w := sessionRequest(sessions, http.MethodHead, "/"+id, nil, "")
+	if w.Code != http.StatusOK {
+		return strconv.Itoa(w.Code)
+	}
+	return w.Header().Get("Upload-Offset")
+}
+
+func patch(sessions *actions.UploadSessions, id, offset, body string) *httptest.ResponseRecorder {
+	return sessionRequest(sessions, http.MethodPatch, "/"+id, map[string]string{"Upload-Offset": offset}, body)
+}
+
+func TestUploadSessions(t *testing.T) {
+	root := shareDir(t)
+	cfg := &config.UploadConfig{Policy: config.UploadOverwrite, SessionDir: t.TempDir(), SessionMaxSize: 100}
+	sessions, err := actions.NewUploadSessions(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := createSession(t, sessions, "f.txt", 10)
+	if offset := offsetOf(t, sessions, id); offset != "0" {
+		t.Fatal("unexpected initial offset", offset)
+	}
+	if w := patch(sessions, id, "0", "01234"); w.Code != http.StatusNoContent || w.Header().Get("Upload-Offset") != "5" {
+		t.Fatal("unexpected patch", w.Code, w.Header())
+	}
+	w := patch(sessions, id, "3", "34567")
+	if w.Code != http.StatusConflict || w.Header().Get("Upload-Offset") != "5" {
+		t.Fatal("expected an offset mismatch", w.Code, w.Header())
+	}
+
+	// The sessions survive a restart
+	sessions, err = actions.NewUploadSessions(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset := offsetOf(t, sessions, id); offset != "5" {
+		t.Fatal("unexpected reloaded offset", offset)
+	}
+
+	// The last chunk commits the file and ends the session
+	w = patch(sessions, id, "5", "56789")
+	resp := &files.ActionResponse{}
+	protojson.Unmarshal(w.Body.Bytes(), resp)
+	if w.Code != http.StatusOK || resp.IsError {
+		t.Fatal("unexpected commit", w.Code, w.Body.String())
+	}
+	data, err := os.ReadFile(filepath.Join(root, "f.txt"))
+	if err != nil || string(data) != "0123456789" {
+		t.Fatal("unexpected committed content", string(data), err)
+	}
+	if offset := offsetOf(t, sessions, id); offset != "404" {
+		t.Fatal("expected the session to end", offset)
+	}
+	parts, _ := filepath.Glob(filepath.Join(root, ".upload-*"))
+	saved, _ := filepath.Glob(filepath.Join(cfg.SessionDir, "*.json"))
+	if len(parts) > 0 || len(saved) > 0 {
+		t.Fatal("expected the session files to be removed", parts, saved)
+	}
+}
+
+func TestUploadSessionLimits(t *testing.T) {
+	root := shareDir(t)
+	cfg := &config.UploadConfig{Policy: config.UploadOverwrite, SessionDir: t.TempDir(), SessionMaxSize: 100}
+	sessions, err := actions.NewUploadSessions(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A body longer than the declared length is cut at the length
+	id := createSession(t, sessions, "short.txt", 3)
+	if w := patch(sessions, id, "0", "abcdef"); w.Code != http.StatusBadRequest {
+		t.Fatal("expected the chunk to exceed the declared length", w.Code)
+	}
+	if offset := offsetOf(t, sessions, id); offset != "3" {
+		t.Fatal("unexpected offset after a long chunk", offset)
+	}
+	if _, err = os.Stat(filepath.Join(root, "short.txt")); !os.IsNotExist(err) {
+		t.Fatal("expected the long upload not to be committed", err)
+	}
+
+	// Delete aborts the upload
+	id = createSession(t, sessions, "aborted.txt", 10)
+	patch(sessions, id, "0", "01234")
+	if w := sessionRequest(sessions, http.MethodDelete, "/"+id, nil, ""); w.Code != http.StatusNoContent {
+		t.Fatal("unexpected delete", w.Code)
+	}
+	if offset := offsetOf(t, sessions, id); offset != "404" {
+		t.Fatal("expected the session to be removed", offset)
+	}
+	if parts, _ := filepath.Glob(filepath.Join(root, ".upload-"+id+"*")); len(parts) > 0 {
+		t.Fatal("expected the part file to be removed", parts)
+	}
+
+	w := sessionRequest(sessions, http.MethodPost, "?path=/data&name=big.txt", map[string]string{"Upload-Length": "101"}, "")
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatal("expected the session maximum size to apply", w.Code)
+	}
+
+	// Without a session maximum, the maximum size of a request applies
+	cfg = &config.UploadConfig{Policy: config.UploadOverwrite, SessionDir: t.TempDir(), MaxSize: 50}
+	sessions, err = actions.NewUploadSessions(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = sessionRequest(sessions, http.MethodPost, "?path=/data&name=big.txt", map[string]string{"Upload-Length": "51"}, "")
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatal("expected the upload maximum size to apply", w.Code)
+	}
+	createSession(t, sessions, "small.txt", 50)
+}
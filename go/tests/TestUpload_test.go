@@ -0,0 +1,177 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8nasfile/go/nas/actions"
+	"github.com/saichler/l8nasfile/go/nas/config"
+	"github.com/saichler/l8nasfile/go/types/files"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// upload posts the body to the UploadHandler and returns the status and the
+// decoded response.
+func upload(t *testing.T, cfg *config.UploadConfig, query, contentType string, body []byte) (int, *files.ActionResponse) {
+	r := httptest.NewRequest(http.MethodPost, "/files/upload?"+query, bytes.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	actions.UploadHandler(w, r, nil, cfg)
+	resp := &files.ActionResponse{}
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		if err := protojson.Unmarshal(w.Body.Bytes(), resp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return w.Code, resp
+}
+
+func multipartBody(t *testing.T, parts map[string]string) (string, []byte) {
+	buf := &bytes.Buffer{}
+	mw := multipart.NewWriter(buf)
+	for name, content := range parts {
+		part, err := mw.CreateFormFile("file", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(content))
+	}
+	mw.Close()
+	return mw.FormDataContentType(), buf.Bytes()
+}
+
+// checkNoTemp fails if an upload left a temporary file behind in dir.
+func checkNoTemp(t *testing.T, dir string) {
+	tmp, _ := filepath.Glob(filepath.Join(dir, ".upload-*.tmp"))
+	if len(tmp) > 0 {
+		t.Fatal("temporary files left behind", tmp)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	root := shareDir(t)
+	cfg := &config.UploadConfig{MaxSize: 1024, Policy: config.UploadOverwrite}
+
+	contentType, body := multipartBody(t, map[string]string{"a.txt": "alpha", "b.txt": "beta"})
+	status, resp := upload(t, cfg, "path=/data", contentType, body)
+	if status != http.StatusOK || resp.IsError || len(resp.Results) != 2 {
+		t.Fatal("unexpected multipart response", status, resp)
+	}
+	for name, content := range map[string]string{"a.txt": "alpha", "b.txt": "beta"} {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil || string(data) != content {
+			t.Fatal("unexpected uploaded content", name, string(data), err)
+		}
+	}
+
+	status, resp = upload(t, cfg, "path=/data&name=c.txt", "application/octet-stream", []byte("gamma"))
+	if status != http.StatusOK || resp.IsError {
+		t.Fatal("unexpected raw response", status, resp)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "c.txt")); string(data) != "gamma" {
+		t.Fatal("unexpected raw content", string(data))
+	}
+
+	status, _ = upload(t, cfg, "path=/data", "application/octet-stream", []byte("x"))
+	if status != http.StatusBadRequest {
+		t.Fatal("expected a raw upload without a name to fail", status)
+	}
+	status, _ = upload(t, cfg, "path=/etc&name=x", "application/octet-stream", []byte("x"))
+	if status != http.StatusForbidden {
+		t.Fatal("expected an upload outside the shares to be denied", status)
+	}
+	checkNoTemp(t, root)
+}
+
+func TestUploadPolicies(t *testing.T) {
+	tests := []struct {
+		policy  string
+		content string
+		renamed string
+		msg     string
+	}{
+		{config.UploadOverwrite, "new", "", "uploaded"},
+		{config.UploadSkip, "old", "", "skipped, already exists"},
+		{config.UploadRename, "old", "new", "uploaded"},
+	}
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			root := shareDir(t)
+			os.WriteFile(filepath.Join(root, "a.txt"), []byte("old"), 0644)
+
+			// The configured policy is the default of the requests without one
+			cfg := &config.UploadConfig{Policy: test.policy}
+			status, resp := upload(t, cfg, "path=/data&name=a.txt", "text/plain", []byte("new"))
+			if status != http.StatusOK || len(resp.Results) != 1 || !strings.HasSuffix(resp.Results[0].Msg, test.msg) {
+				t.Fatal("unexpected response", status, resp)
+			}
+			data, _ := os.ReadFile(filepath.Join(root, "a.txt"))
+			if string(data) != test.content {
+				t.Fatal("unexpected content", string(data))
+			}
+			renamed, _ := os.ReadFile(filepath.Join(root, "a (1).txt"))
+			if string(renamed) != test.renamed {
+				t.Fatal("unexpected renamed content", string(renamed))
+			}
+			checkNoTemp(t, root)
+		})
+	}
+
+	root := shareDir(t)
+	cfg := &config.UploadConfig{Policy: config.UploadRename}
+	status, _ := upload(t, cfg, "path=/data&name=a.txt&policy=merge", "text/plain", []byte("new"))
+	if status != http.StatusBadRequest {
+		t.Fatal("expected an invalid policy to fail", status)
+	}
+	status, _ = upload(t, cfg, "path=/data&name=a.txt&policy=overwrite", "text/plain", []byte("new"))
+	if data, _ := os.ReadFile(filepath.Join(root, "a.txt")); status != http.StatusOK || string(data) != "new" {
+		t.Fatal("expected the request policy to override the configuration", status, string(data))
+	}
+}
+
+func TestUploadMaxSize(t *testing.T) {
+	root := shareDir(t)
+	cfg := &config.UploadConfig{MaxSize: 8, Policy: config.UploadOverwrite}
+
+	// A declared length over the limit is refused before reading the body
+	status, _ := upload(t, cfg, "path=/data&name=big.txt", "text/plain", []byte("0123456789"))
+	if status != http.StatusRequestEntityTooLarge {
+		t.Fatal("expected the upload to be too large", status)
+	}
+
+	// A body of unknown length is cut at the limit while it is written
+	contentType, body := multipartBody(t, map[string]string{"big.txt": "0123456789"})
+	r := httptest.NewRequest(http.MethodPost, "/files/upload?path=/data", bytes.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+	r.ContentLength = -1
+	w := httptest.NewRecorder()
+	actions.UploadHandler(w, r, nil, cfg)
+	if w.Code == http.StatusOK {
+		t.Fatal("expected the upload to fail at the limit")
+	}
+	if _, err := os.Stat(filepath.Join(root, "big.txt")); !os.IsNotExist(err) {
+		t.Fatal("expected no partial file", err)
+	}
+	checkNoTemp(t, root)
+}
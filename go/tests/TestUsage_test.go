@@ -0,0 +1,67 @@
+/*
+ * © 2025 Sharon Aicler (saichler@gmail.com)
+ *
+ * Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+ * You may obtain a copy of the License at:
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/saichler/l8nasfile/go/nas/shares"
+	"github.com/saichler/l8nasfile/go/nas/usage"
+	"github.com/saichler/l8nasfile/go/types/files"
+)
+
+func TestUsage(t *testing.T) {
+	root := t.TempDir()
+	err := shares.Configure([]*shares.Share{{Name: "data", Root: root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "videos", "2020"), 0755)
+	os.MkdirAll(filepath.Join(root, "docs"), 0755)
+	os.WriteFile(filepath.Join(root, "videos", "2020", "a.mp4"), make([]byte, 5000), 0644)
+	os.WriteFile(filepath.Join(root, "videos", "b.MP4"), make([]byte, 3000), 0644)
+	os.WriteFile(filepath.Join(root, "docs", "c.txt"), make([]byte, 100), 0644)
+	os.WriteFile(filepath.Join(root, "docs", "d.txt"), make([]byte, 50), 0644)
+	old := time.Now().Add(-2 * 365 * 24 * time.Hour)
+	os.Chtimes(filepath.Join(root, "videos", "2020", "a.mp4"), old, old)
+
+	report, err := usage.Analyze(&files.UsageRequest{Path: "/data", Depth: 1, Top: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Root.Name != "data" || report.Root.Size != 8150 || report.Root.Files != 4 || report.Root.Directories != 3 {
+		t.Fatal("unexpected root totals", report.Root)
+	}
+	if len(report.Root.Children) != 1 || report.Root.Children[0].Path != "/data" || report.Root.Children[0].Name != "videos" {
+		t.Fatal("expected only the largest child", report.Root.Children)
+	}
+	if report.Root.Others != 1 || report.Root.OthersSize != 150 {
+		t.Fatal("expected the smaller child as others", report.Root)
+	}
+	if len(report.Root.Children[0].Children) != 0 {
+		t.Fatal("expected no children below the requested depth")
+	}
+	if report.Extensions[0].Name != ".mp4" || report.Extensions[0].Size != 8000 || report.Extensions[0].Files != 2 {
+		t.Fatal("unexpected extension breakdown", report.Extensions)
+	}
+	older := report.Ages[len(report.Ages)-1]
+	if older.Size != 5000 || report.Ages[0].Size != 3150 {
+		t.Fatal("unexpected age breakdown", report.Ages)
+	}
+}